@@ -0,0 +1,276 @@
+package ens
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+
+	"github.com/things-go/ens/utils"
+)
+
+// association gorm association field, like BelongsTo, HasOne, HasMany, Many2Many.
+type association struct {
+	GoName string   // Go name
+	GoType string   // Go type, like *User, []*Post
+	Tags   []string // struct tag
+}
+
+func (a *association) String() string {
+	return fmt.Sprintf("%s %s `%s`", a.GoName, a.GoType, strings.Join(a.Tags, " "))
+}
+
+// associationResolver resolve the associations from the foreign keys.
+type associationResolver struct {
+	opt      *Option
+	entities map[string]*EntityDescriptor // all entities
+	fixed    map[string]struct{}          // the entities has been fixed by fixEntityField
+	goNames  map[string]map[string]string // entity name -> column name -> Go name
+}
+
+// resolveAssociations resolve the associations of the entities, the referenced entity must be in all entities.
+// NOTE: the entities must be fixed by fixEntityField, all entities include the entities.
+func resolveAssociations(entities, all []*EntityDescriptor, opt *Option) map[string][]*association {
+	r := &associationResolver{
+		opt:      opt,
+		entities: make(map[string]*EntityDescriptor, len(all)+len(entities)),
+		fixed:    make(map[string]struct{}, len(entities)),
+		goNames:  make(map[string]map[string]string, len(all)+len(entities)),
+	}
+	for _, et := range all {
+		r.entities[et.Name] = et
+	}
+	for _, et := range entities {
+		r.entities[et.Name] = et
+		r.fixed[et.Name] = struct{}{}
+	}
+	result := make(map[string][]*association, len(entities))
+	for _, et := range entities {
+		if v := r.resolve(et, all); len(v) > 0 {
+			result[et.Name] = v
+		}
+	}
+	return result
+}
+
+func (r *associationResolver) resolve(et *EntityDescriptor, all []*EntityDescriptor) []*association {
+	used := make(map[string]struct{}, len(et.Fields)+len(mustEscapeNames)+len(r.opt.EscapeName))
+	for _, v := range mustEscapeNames {
+		used[v] = struct{}{}
+	}
+	for _, v := range r.opt.EscapeName {
+		used[v] = struct{}{}
+	}
+	for _, field := range et.Fields {
+		used[field.GoName] = struct{}{}
+	}
+	associations := make([]*association, 0, 8)
+	add := func(name, goType string, settings ...string) {
+		for { // 与字段或转义名称冲突时, 同 fixField 一样加 X 前缀
+			if _, ok := used[name]; !ok {
+				break
+			}
+			name = "X" + name
+		}
+		used[name] = struct{}{}
+		associations = append(associations, &association{
+			GoName: name,
+			GoType: goType,
+			Tags:   r.tags(name, settings),
+		})
+	}
+
+	//* belongs to
+	for _, fk := range et.ForeignKeys {
+		if _, ok := r.entities[fk.RefTable]; !ok {
+			continue
+		}
+		add(
+			belongsToName(fk),
+			"*"+utils.PascalCase(fk.RefTable),
+			"foreignKey:"+r.fieldNames(et.Name, fk.Columns),
+			"references:"+r.fieldNames(fk.RefTable, fk.RefColumns),
+		)
+	}
+	//* has one, has many
+	for _, child := range all {
+		if r.isJoinTable(child) {
+			continue
+		}
+		refCount := 0
+		for _, fk := range child.ForeignKeys {
+			if fk.RefTable == et.Name {
+				refCount++
+			}
+		}
+		for _, fk := range child.ForeignKeys {
+			if fk.RefTable != et.Name {
+				continue
+			}
+			name, goType := utils.PascalCase(child.Name), "*"+utils.PascalCase(child.Name)
+			if !isUniqueColumns(child, fk.Columns) {
+				name, goType = inflection.Plural(name), "[]"+goType
+			}
+			if refCount > 1 { // 同一个表有多个外键引用时, 加上外键名称前缀
+				name = belongsToName(fk) + name
+			}
+			add(
+				name,
+				goType,
+				"foreignKey:"+r.fieldNames(child.Name, fk.Columns),
+				"references:"+r.fieldNames(et.Name, fk.RefColumns),
+			)
+		}
+	}
+	//* many to many
+	for _, join := range all {
+		if !r.isJoinTable(join) {
+			continue
+		}
+		owner, ref := join.ForeignKeys[0], join.ForeignKeys[1]
+		if ref.RefTable == et.Name {
+			owner, ref = ref, owner
+		}
+		if owner.RefTable != et.Name || ref.RefTable == et.Name {
+			continue
+		}
+		add(
+			inflection.Plural(utils.PascalCase(ref.RefTable)),
+			"[]*"+utils.PascalCase(ref.RefTable),
+			"many2many:"+join.Name,
+			"foreignKey:"+r.fieldNames(et.Name, owner.RefColumns),
+			"joinForeignKey:"+r.fieldNames(join.Name, owner.Columns),
+			"references:"+r.fieldNames(ref.RefTable, ref.RefColumns),
+			"joinReferences:"+r.fieldNames(join.Name, ref.Columns),
+		)
+	}
+	return associations
+}
+
+func (r *associationResolver) tags(goName string, settings []string) []string {
+	tags := []string{fmt.Sprintf(`gorm:"%s"`, strings.Join(settings, ";"))}
+	keys := make([]string, 0, len(r.opt.Tags))
+	for tag := range r.opt.Tags {
+		keys = append(keys, tag)
+	}
+	slices.Sort(keys)
+	for _, tag := range keys {
+		if r.opt.Tags[tag] == utils.StyleValidator { // 关联字段无列校验规则
+			continue
+		}
+		vv := utils.StyleName(r.opt.Tags[tag], utils.SnakeCase(goName))
+		if vv == "" {
+			continue
+		}
+		if r.opt.IgnoreOmitempty {
+			tags = append(tags, fmt.Sprintf(`%s:"%s"`, tag, vv))
+		} else {
+			tags = append(tags, fmt.Sprintf(`%s:"%s,omitempty"`, tag, vv))
+		}
+	}
+	return tags
+}
+
+// fieldNames returns the Go field names of the columns, joined with `,`.
+func (r *associationResolver) fieldNames(entityName string, columns []string) string {
+	goNames, ok := r.goNames[entityName]
+	if !ok {
+		goNames = make(map[string]string)
+		if et, ok := r.entities[entityName]; ok {
+			if _, fixed := r.fixed[entityName]; fixed {
+				for _, field := range et.Fields {
+					goNames[field.ColumnName] = field.GoName
+				}
+			} else {
+				goNames = escapedGoNames(et, r.opt)
+			}
+		}
+		r.goNames[entityName] = goNames
+	}
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if v, ok := goNames[column]; ok {
+			names = append(names, v)
+		} else {
+			names = append(names, utils.PascalCase(column))
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// isJoinTable reports whether the entity is a many to many join table,
+// it has two foreign keys to the other entities, and the foreign key columns are the primary key or unique.
+func (r *associationResolver) isJoinTable(et *EntityDescriptor) bool {
+	if len(et.ForeignKeys) != 2 {
+		return false
+	}
+	columns := make([]string, 0, 4)
+	for _, fk := range et.ForeignKeys {
+		if _, ok := r.entities[fk.RefTable]; !ok || fk.RefTable == et.Name {
+			return false
+		}
+		columns = append(columns, fk.Columns...)
+	}
+	return et.ForeignKeys[0].RefTable != et.ForeignKeys[1].RefTable && isUniqueColumns(et, columns)
+}
+
+// isUniqueColumns reports whether the columns are the primary key or an unique index.
+func isUniqueColumns(et *EntityDescriptor, columns []string) bool {
+	if et.Table == nil {
+		return false
+	}
+	equal := func(a, b []string) bool {
+		a, b = slices.Clone(a), slices.Clone(b)
+		slices.Sort(a)
+		slices.Sort(b)
+		return slices.Equal(a, b)
+	}
+	if pk := et.Table.PrimaryKey(); pk != nil && pk.Index() != nil {
+		var pkColumns []string
+		for _, part := range pk.Index().Parts {
+			if part.C != nil {
+				pkColumns = append(pkColumns, part.C.Name)
+			}
+		}
+		if equal(pkColumns, columns) {
+			return true
+		}
+	}
+	for _, index := range et.Indexes {
+		if index.Index != nil && index.Index.Index().Unique && equal(index.Fields, columns) {
+			return true
+		}
+	}
+	return false
+}
+
+// belongsToName returns the belongs to field name, `user_id` -> `User`, otherwise the reference table name.
+func belongsToName(fk *ForeignKeyDescriptor) string {
+	if len(fk.Columns) == 1 {
+		if name, ok := strings.CutSuffix(fk.Columns[0], "_id"); ok && name != "" {
+			return utils.PascalCase(name)
+		}
+	}
+	return utils.PascalCase(fk.RefTable)
+}
+
+// escapedGoNames returns the Go names of the entity fields after escaped, same as fixEntityField but not modify the entity.
+func escapedGoNames(et *EntityDescriptor, opt *Option) map[string]string {
+	escapeNames := make(map[string]struct{}, len(mustEscapeNames)+len(opt.EscapeName))
+	for _, v := range mustEscapeNames {
+		escapeNames[v] = struct{}{}
+	}
+	for _, k := range opt.EscapeName {
+		escapeNames[k] = struct{}{}
+	}
+	allFieldName := make(map[string]struct{}, len(et.Fields))
+	for _, field := range et.Fields {
+		allFieldName[field.GoName] = struct{}{}
+	}
+	goNames := make(map[string]string, len(et.Fields))
+	for _, field := range et.Fields {
+		goNames[field.ColumnName] = escapeGoName(field.GoName, allFieldName, escapeNames)
+	}
+	return goNames
+}
@@ -0,0 +1,102 @@
+package ens_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/things-go/ens"
+)
+
+func Test_Association(t *testing.T) {
+	sc := parseSQL(t, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `profile` varchar(64) NOT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;\n"+
+		"CREATE TABLE `profile` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `user_id` bigint NOT NULL,\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  UNIQUE KEY `uk_user_id` (`user_id`),\n"+
+		"  CONSTRAINT `fk_profile_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`)\n"+
+		") ENGINE=InnoDB;\n"+
+		"CREATE TABLE `post` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `author_id` bigint NOT NULL,\n"+
+		"  `editor_id` bigint NOT NULL,\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  CONSTRAINT `fk_post_author` FOREIGN KEY (`author_id`) REFERENCES `user` (`id`),\n"+
+		"  CONSTRAINT `fk_post_editor` FOREIGN KEY (`editor_id`) REFERENCES `user` (`id`)\n"+
+		") ENGINE=InnoDB;\n"+
+		"CREATE TABLE `tag` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;\n"+
+		"CREATE TABLE `post_tag` (\n"+
+		"  `post_id` bigint NOT NULL,\n"+
+		"  `tag_id` bigint NOT NULL,\n"+
+		"  PRIMARY KEY (`post_id`,`tag_id`),\n"+
+		"  CONSTRAINT `fk_post_tag_post` FOREIGN KEY (`post_id`) REFERENCES `post` (`id`),\n"+
+		"  CONSTRAINT `fk_post_tag_tag` FOREIGN KEY (`tag_id`) REFERENCES `tag` (`id`)\n"+
+		") ENGINE=InnoDB;")
+
+	// 单个实体生成时, 通过 AllEntities 解析关联.
+	find := func(name string) *ens.EntityDescriptor {
+		for _, et := range sc.Entities {
+			if et.Name == name {
+				return et
+			}
+		}
+		t.Fatalf("entity %s not found", name)
+		return nil
+	}
+	for _, tt := range []struct {
+		entity string
+		wants  []string
+	}{
+		{
+			"user",
+			[]string{
+				"XProfile *Profile `gorm:\"foreignKey:UserId;references:Id\" json:\"x_profile,omitempty\"`",
+				"AuthorPosts []*Post `gorm:\"foreignKey:AuthorId;references:Id\" json:\"author_posts,omitempty\"`",
+				"EditorPosts []*Post `gorm:\"foreignKey:EditorId;references:Id\" json:\"editor_posts,omitempty\"`",
+			},
+		},
+		{
+			"post",
+			[]string{
+				"Author *User `gorm:\"foreignKey:AuthorId;references:Id\" json:\"author,omitempty\"`",
+				"Editor *User `gorm:\"foreignKey:EditorId;references:Id\" json:\"editor,omitempty\"`",
+				"Tags []*Tag `gorm:\"many2many:post_tag;foreignKey:Id;joinForeignKey:PostId;references:Id;joinReferences:TagId\" json:\"tags,omitempty\"`",
+			},
+		},
+		{
+			"tag",
+			[]string{
+				"Posts []*Post `gorm:\"many2many:post_tag;foreignKey:Id;joinForeignKey:TagId;references:Id;joinReferences:PostId\" json:\"posts,omitempty\"`",
+			},
+		},
+	} {
+		g := &ens.CodeGen{
+			Entities:    []*ens.EntityDescriptor{find(tt.entity)},
+			AllEntities: sc.Entities,
+			ByName:      "ormat",
+			Version:     "v1.0.0",
+			PackageName: "model",
+			Option: ens.Option{
+				EnableForeignKey: true,
+				Tags:             map[string]string{"json": "snakeCase"},
+			},
+		}
+		data, err := g.Gen().FormatSource()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := strings.Join(strings.Fields(string(data)), " ") // ignore the alignment
+		for _, want := range tt.wants {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s missing %q, got:\n%s", tt.entity, want, data)
+			}
+		}
+	}
+}
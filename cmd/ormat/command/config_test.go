@@ -0,0 +1,161 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Config_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		target  int               // index of the targets, -1 is the command config only
+		args    map[string]string // command line flags
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "common",
+			config: "out: ./common\nmodel: {}\n",
+			target: -1,
+			key:    "out",
+			want:   "./common",
+		},
+		{
+			name:   "command override common",
+			config: "out: ./common\nmodel:\n  out: ./model\n",
+			target: -1,
+			key:    "out",
+			want:   "./model",
+		},
+		{
+			name:   "target override command",
+			config: "out: ./common\nmodel:\n  out: ./model\ntargets:\n  - command: model\n    out: ./target\n",
+			target: 0,
+			key:    "out",
+			want:   "./target",
+		},
+		{
+			name:   "target inherit command",
+			config: "model:\n  package: entity\ntargets:\n  - command: model\n    out: ./target\n",
+			target: 0,
+			key:    "package",
+			want:   "entity",
+		},
+		{
+			name:   "command line override all",
+			config: "out: ./common\nmodel:\n  out: ./model\ntargets:\n  - command: model\n    out: ./target\n",
+			target: 0,
+			args:   map[string]string{"out": "./cli"},
+			key:    "out",
+			want:   "./cli",
+		},
+		{
+			name:   "nested map",
+			config: "model:\n  tags:\n    json: smallCamelCase\n  customFieldIdent:\n    user:\n      id: int64\n",
+			target: -1,
+			key:    "customFieldIdent",
+			want:   "[user.id=int64]",
+		},
+		{
+			name:   "common unknown key ignored",
+			config: "toSchema: gomodel\nmodel:\n  out: ./model\n",
+			target: -1,
+			key:    "out",
+			want:   "./model",
+		},
+		{
+			name:    "command unknown key",
+			config:  "model:\n  toSchema: gomodel\n",
+			target:  -1,
+			wantErr: true,
+		},
+		{
+			name:    "target unknown key",
+			config:  "targets:\n  - command: model\n    foo: bar\n",
+			target:  0,
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			config:  "model:\n  disableDocComment: foo\n",
+			target:  -1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "ormat.yaml")
+			if err := os.WriteFile(filename, []byte(tt.config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			c, err := loadConfig(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var target map[string]any
+			if tt.target >= 0 {
+				target = c.Targets[tt.target]
+			}
+			cmd := newModelCmd().cmd
+			for k, v := range tt.args {
+				if err = cmd.Flags().Set(k, v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err = c.apply(cmd, target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := cmd.Flags().Lookup(tt.key).Value.String(); got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_LoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{"valid", "url: mysql://root@127.0.0.1/test\nmodel: {}\nsql:\ntargets:\n  - command: proto\n", false},
+		{"targets not list", "targets: {command: model}\n", true},
+		{"target unknown command", "targets:\n  - command: foo\n", true},
+		{"command not map", "model: ./model\n", true},
+		{"invalid yaml", "model: [\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "ormat.yaml")
+			if err := os.WriteFile(filename, []byte(tt.config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			c, err := loadConfig(filename)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if c.Common["url"] != "mysql://root@127.0.0.1/test" || len(c.Commands) != 2 || len(c.Targets) != 1 {
+				t.Errorf("unexpected config: %+v", c)
+			}
+		})
+	}
+
+	// the default config file is optional, the specified one is required.
+	t.Chdir(t.TempDir())
+	if _, err := loadConfig(""); err != nil {
+		t.Errorf("default config file should be optional, got: %v", err)
+	}
+	if _, err := loadConfig("not_exist.yaml"); err == nil {
+		t.Error("want error of the specified config file not exist")
+	}
+}
@@ -1,18 +1,23 @@
 package command
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"ariga.io/atlas/sql/schema"
+	"github.com/spf13/pflag"
 	"github.com/things-go/ens"
 	"github.com/things-go/ens/driver"
+	"github.com/things-go/ens/internal/migrate"
 )
 
 func LoadDriver(URL string) (driver.Driver, error) {
@@ -35,9 +40,76 @@ type source struct {
 	URL     string
 	Tables  []string
 	Exclude []string
+	// 自定义字段类型, 格式: TableName.ColumnName->Ident, 如 user.price=github.com/shopspring/decimal.Decimal
+	CustomFieldIdent map[string]string
+	// json列绑定的类型, 格式: TableName.ColumnName->Type, 如 user.settings=Settings, user.tags=[]Tag, user.extra=map
+	JSONType map[string]string
+	// 可切换的列类型映射
+	TypeOption ens.TypeOption
 }
 
+// schemaUsage the usage of the `-s` flag, lists the registered file drivers,
+// file+sqlite is registered only when build with tags sqlite3(cgo).
+func schemaUsage(withGoModel bool) string {
+	names := slices.DeleteFunc(driver.DriverNames(), func(name string) bool {
+		return !strings.HasPrefix(name, "file+") && !(withGoModel && name == driver.GoModel)
+	})
+	slices.Sort(names)
+	return "parser file driver, [" + strings.Join(names, ",") + "](仅input时有效)"
+}
+
+const customFieldIdentUsage = "自定义字段类型, 格式: TableName.ColumnName=Ident, Ident 可带完整导入路径, 如 user.price=github.com/shopspring/decimal.Decimal"
+
+const jsonTypeUsage = "json列绑定的类型, 格式: TableName.ColumnName=Type, 如 user.settings=Settings(datatypes.JSONType), user.tags=[]Tag(datatypes.JSONSlice), user.extra=map(datatypes.JSONMap)"
+
+// addTypeOptionFlags add the flags of the switchable mappings of the column types.
+func addTypeOptionFlags(flags *pflag.FlagSet, o *ens.TypeOption) {
+	flags.BoolVar(&o.EnableDatatypesDate, "enableDatatypesDate", false, "使能date输出datatypes.Date, proto为google.type.Date")
+	flags.BoolVar(&o.EnableDatatypesTime, "enableDatatypesTime", false, "使能time输出datatypes.Time, proto为string")
+	flags.BoolVar(&o.EnableYearInt, "enableYearInt", false, "使能year输出int16")
+	flags.BoolVar(&o.EnableDecimal, "enableDecimal", false, "使能decimal输出decimal.Decimal(github.com/shopspring/decimal), proto为string")
+	flags.BoolVar(&o.EnableUUID, "enableUUID", false, "使能binary(16)输出datatypes.BinUUID, char(36),uuid输出datatypes.UUID, proto为string")
+	flags.BoolVar(&o.EnableBitBool, "enableBitBool", false, "使能bit(1)输出bool")
+}
+
+// getSchema inspect the schema, apply the switchable mappings of the column types,
+// and override the go type of the custom field ident and json type.
 func getSchema(c *source) (*ens.Schema, error) {
+	sc, err := inspectSchema(c)
+	if err != nil {
+		return nil, err
+	}
+	sc.ApplyTypeOption(&c.TypeOption)
+	if len(c.CustomFieldIdent) > 0 {
+		if err = sc.OverrideGoType(customFieldIdent(c.CustomFieldIdent)); err != nil {
+			return nil, fmt.Errorf("customFieldIdent: %w", err)
+		}
+	}
+	if len(c.JSONType) > 0 {
+		if err = sc.OverrideJSONType(customFieldIdent(c.JSONType)); err != nil {
+			return nil, fmt.Errorf("jsonType: %w", err)
+		}
+	}
+	return sc, nil
+}
+
+// customFieldIdent convert `TableName.ColumnName->Ident` to `TableName -> ColumnName -> Ident`.
+func customFieldIdent(m map[string]string) map[string]map[string]string {
+	idents := map[string]map[string]string{}
+	for key, val := range m {
+		tb, column, ok := strings.Cut(key, ".")
+		if !ok || tb == "" || column == "" || val == "" {
+			continue
+		}
+		if idents[tb] == nil {
+			idents[tb] = map[string]string{}
+		}
+		idents[tb][column] = val
+	}
+	return idents
+}
+
+func inspectSchema(c *source) (*ens.Schema, error) {
 	if c.URL != "" {
 		u, err := url.Parse(c.URL)
 		if err != nil {
@@ -61,14 +133,43 @@ func getSchema(c *source) (*ens.Schema, error) {
 		if err != nil {
 			return nil, err
 		}
-		inputFile, err := filepath.Glob(c.InputFile[0])
+		if c.Schema == driver.GoModel {
+			// gomodel 输入为go模型所在的包目录.
+			return d.InspectSchema(context.Background(), &driver.InspectOption{
+				URL: driver.GoModel + "://" + c.InputFile[0],
+				InspectOptions: schema.InspectOptions{
+					Mode:    schema.InspectTables,
+					Tables:  c.Tables,
+					Exclude: c.Exclude,
+				},
+			})
+		}
+		inputFile, err := expandInputFile(c.InputFile)
 		if err != nil {
 			return nil, err
 		}
-		if len(inputFile) == 1 && inputFile[0] == c.InputFile[0] {
-			inputFile = c.InputFile
+		if c.Schema == driver.FileMysqlTidb {
+			// file+tidb 按顺序重放语句(ALTER TABLE, CREATE INDEX ...), 所以合并后一次解析.
+			b := strings.Builder{}
+			for _, filename := range inputFile {
+				content, err := os.ReadFile(filename)
+				if err != nil {
+					return nil, err
+				}
+				b.Write(content)
+				if !strings.HasSuffix(strings.TrimSpace(string(content)), ";") {
+					b.WriteString(";")
+				}
+				b.WriteString("\n")
+			}
+			return d.InspectSchema(context.Background(), &driver.InspectOption{
+				URL:            "",
+				Data:           b.String(),
+				InspectOptions: schema.InspectOptions{},
+			})
 		}
 		entities := make([]*ens.EntityDescriptor, 0, 256)
+		entityIndex := make(map[string]int, 256)
 		for _, filename := range inputFile {
 			tmpSc, err := func() (*ens.Schema, error) {
 				content, err := os.ReadFile(filename)
@@ -85,7 +186,15 @@ func getSchema(c *source) (*ens.Schema, error) {
 				slog.Warn("🧐 parse failed !!!", slog.String("file", filename), slog.Any("error", err))
 				continue
 			}
-			entities = append(entities, tmpSc.Entities...)
+			// 同名表以后解析的为准
+			for _, entity := range tmpSc.Entities {
+				if i, ok := entityIndex[entity.Name]; ok {
+					entities[i] = entity
+				} else {
+					entityIndex[entity.Name] = len(entities)
+					entities = append(entities, entity)
+				}
+			}
 		}
 		return &ens.Schema{
 			Name:     "",
@@ -96,6 +205,44 @@ func getSchema(c *source) (*ens.Schema, error) {
 	return nil, errors.New("at least one of [url input] is required")
 }
 
+// expandInputFile expand the directory(*.sql) and glob pattern of the input files, the expanded files
+// are sorted by the migration version(the "down" migration ignored), so 9 before 10,
+// the files without version are sorted by name after them, the explicit files keep the order of the user.
+func expandInputFile(input []string) ([]string, error) {
+	files := make([]string, 0, len(input))
+	for _, v := range input {
+		pattern := v
+		if fi, err := os.Stat(v); err == nil && fi.IsDir() {
+			pattern = filepath.Join(v, "*.sql")
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 || len(matches) == 1 && matches[0] == v { // explicit file
+			files = append(files, v)
+			continue
+		}
+		matches = slices.DeleteFunc(matches, func(filename string) bool {
+			version, up := migrate.Version(filepath.Base(filename))
+			return version != "" && !up
+		})
+		slices.SortFunc(matches, func(a, b string) int {
+			va, _ := migrate.Version(filepath.Base(a))
+			vb, _ := migrate.Version(filepath.Base(b))
+			switch {
+			case va == "" && vb != "":
+				return 1
+			case va != "" && vb == "":
+				return -1
+			}
+			return cmp.Or(migrate.CompareVersion(va, vb), strings.Compare(a, b))
+		})
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
 func joinFilename(dir, filename, suffix string) string {
 	suffix = strings.TrimSpace(suffix)
 	if suffix != "" && !strings.HasPrefix(suffix, ".") {
@@ -0,0 +1,45 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func Test_expandInputFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"10_b.up.sql", "10_b.down.sql", "9_a.sql", "init.sql", "README.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	join := func(names ...string) []string {
+		for i, name := range names {
+			names[i] = filepath.Join(dir, name)
+		}
+		return names
+	}
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{"explicit order", join("init.sql", "10_b.up.sql", "9_a.sql"), join("init.sql", "10_b.up.sql", "9_a.sql")},
+		{"directory", []string{dir}, join("9_a.sql", "10_b.up.sql", "init.sql")},
+		{"glob", join("*.sql"), join("9_a.sql", "10_b.up.sql", "init.sql")},
+		{"mixed", append(join("init.sql"), filepath.Join(dir, "*_*.sql")), join("init.sql", "9_a.sql", "10_b.up.sql")},
+		{"not exist", join("not_exist.sql"), join("not_exist.sql")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandInputFile(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,12 +7,15 @@ import (
 
 	"github.com/spf13/cobra"
 
+	_ "github.com/things-go/ens/driver/gomodel"
 	_ "github.com/things-go/ens/driver/mysql"
+	_ "github.com/things-go/ens/driver/postgres"
 )
 
 type RootCmd struct {
-	cmd   *cobra.Command
-	level string
+	cmd    *cobra.Command
+	level  string
+	config string
 }
 
 func NewRootCmd() *RootCmd {
@@ -25,6 +28,16 @@ func NewRootCmd() *RootCmd {
 		SilenceUsage:  false,
 		SilenceErrors: false,
 		Args:          cobra.NoArgs,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if cmd.Name() == "run" { // run 命令自行加载配置
+				return nil
+			}
+			c, err := loadConfig(root.config)
+			if err != nil {
+				return err
+			}
+			return c.apply(cmd, nil)
+		},
 	}
 	cobra.OnInitialize(func() {
 		textHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
@@ -36,11 +49,15 @@ func NewRootCmd() *RootCmd {
 	})
 
 	cmd.PersistentFlags().StringVarP(&root.level, "level", "l", "info", "log level(debug,info,warn,error)")
+	cmd.PersistentFlags().StringVarP(&root.config, "config", "c", "", "config file, default "+defaultConfigFile+" if exist")
 	cmd.AddCommand(
 		newSqlCmd().cmd,
+		newDiffCmd().cmd,
 		newModelCmd().cmd,
 		newProtoCmd().cmd,
 		newRapierCmd().cmd,
+		newGenCmd().cmd,
+		newRunCmd(root).cmd,
 	)
 	root.cmd = cmd
 	return root
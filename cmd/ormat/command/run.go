@@ -0,0 +1,82 @@
+package command
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+)
+
+type runCmd struct {
+	cmd *cobra.Command
+}
+
+func newRunCmd(root *RootCmd) *runCmd {
+	cmd := &cobra.Command{
+		Use:     "run",
+		Short:   "Generate all targets from config file",
+		Long:    "generate all targets in config file, if targets is empty, generate the commands configured in order " + fmt.Sprint(commandNames),
+		Example: "ormat run -c ormat.yaml",
+		RunE: func(*cobra.Command, []string) error {
+			c, err := loadConfig(root.config)
+			if err != nil {
+				return err
+			}
+			targets := c.Targets
+			if len(targets) == 0 {
+				for _, name := range commandNames {
+					if _, ok := c.Commands[name]; ok {
+						targets = append(targets, map[string]any{"command": name})
+					}
+				}
+			}
+			if len(targets) == 0 {
+				return fmt.Errorf("no targets found in config file")
+			}
+			for i, target := range targets {
+				name := fmt.Sprint(target["command"])
+				slog.Info("🚀 run target", slog.Int("index", i), slog.String("command", name))
+				if err = runTarget(c, newTargetCmd(name), target); err != nil {
+					return fmt.Errorf("targets[%d] %s: %w", i, name, err)
+				}
+			}
+			return nil
+		},
+	}
+	return &runCmd{cmd: cmd}
+}
+
+func newTargetCmd(name string) *cobra.Command {
+	switch name {
+	case "sql":
+		return newSqlCmd().cmd
+	case "diff":
+		return newDiffCmd().cmd
+	case "model":
+		return newModelCmd().cmd
+	case "proto":
+		return newProtoCmd().cmd
+	case "gen":
+		return newGenCmd().cmd
+	default: // rapier
+		return newRapierCmd().cmd
+	}
+}
+
+// runTarget run the command like cobra execute, but the flags are from the config.
+func runTarget(c *Config, cmd *cobra.Command, target map[string]any) error {
+	err := c.apply(cmd, target)
+	if err != nil {
+		return err
+	}
+	if err = cmd.ValidateRequiredFlags(); err != nil {
+		return err
+	}
+	if err = cmd.ValidateFlagGroups(); err != nil {
+		return err
+	}
+	if cmd.PreRun != nil {
+		cmd.PreRun(cmd, nil)
+	}
+	return cmd.RunE(cmd, nil)
+}
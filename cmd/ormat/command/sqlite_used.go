@@ -6,6 +6,9 @@ package command
 import (
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+
+	// sqlite and file+sqlite need the cgo database/sql driver "sqlite3" which registered by gorm.io/driver/sqlite.
+	_ "github.com/things-go/ens/driver/sqlite"
 )
 
 func NewSqlite3(dsn string) gorm.Dialector {
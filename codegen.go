@@ -3,6 +3,7 @@ package ens
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/things-go/ens/matcher"
@@ -20,6 +21,7 @@ type CodeGen struct {
 	PackageName       string                       // 包名
 	DisableDocComment bool                         // 标用文档注释
 	CustomFieldIdent  map[string]map[string]string // 自定义字段Ident, TableName -> ColumnName -> Ident
+	AllEntities       []*EntityDescriptor          // 所有实体, 用于解析外键关联(EnableForeignKey), 为空时使用 Entities
 	Option
 }
 
@@ -84,8 +86,31 @@ func (g *CodeGen) Gen() *CodeGen {
 	g.Println()                             // nolint: errcheck
 
 	//* 先处理转义, 主要是一些需要导入的包, 各种选项. 避免格式化耗时.
+	enums := make(map[string][]*EnumDescriptor, len(g.Entities))
 	for _, et := range g.Entities {
-		et.fixEntityField(&g.Option)
+		for _, field := range et.Fields {
+			ident := g.CustomFieldIdent[et.Name][field.ColumnName]
+			if ident == "" {
+				continue
+			}
+			if err := field.overrideGoType(ident); err != nil { // 无法解析时原样输出
+				field.Type = GoType{Type: field.Type.Type, Ident: ident}
+				field.GoPointer = false
+				field.CustomType = true
+			}
+		}
+		if v := et.fixEntityField(&g.Option); len(v) > 0 {
+			enums[et.Name] = v
+		}
+	}
+	//* 外键关联
+	var associations map[string][]*association
+	if g.EnableForeignKey {
+		all := g.AllEntities
+		if len(all) == 0 {
+			all = g.Entities
+		}
+		associations = resolveAssociations(g.Entities, all, &g.Option)
 	}
 	//* import
 	imports := make(map[string]struct{})
@@ -94,8 +119,16 @@ func (g *CodeGen) Gen() *CodeGen {
 			if field.Type.PkgPath != "" {
 				imports[field.Type.PkgPath] = struct{}{}
 			}
+			if field.Type.ElemPkgPath != "" {
+				imports[field.Type.ElemPkgPath] = struct{}{}
+			}
 		}
 	}
+	if len(enums) > 0 {
+		imports["fmt"] = struct{}{}
+		imports["database/sql"] = struct{}{}
+		imports["database/sql/driver"] = struct{}{}
+	}
 	if len(imports) > 0 {
 		g.Println("import (") // nolint: errcheck
 		for k := range imports {
@@ -110,7 +143,10 @@ func (g *CodeGen) Gen() *CodeGen {
 		g.Printf("// %s %s\n", structName, strings.ReplaceAll(strings.TrimSpace(et.Comment), "\n", "\n// ")) // nolint: errcheck
 		g.Printf("type %s struct {\n", structName)                                                           // nolint: errcheck
 		for _, field := range et.Fields {
-			g.Println(g.genModelStructField(field, g.CustomFieldIdent[et.Name])) // nolint: errcheck
+			g.Println(g.genModelStructField(field)) // nolint: errcheck
+		}
+		for _, v := range associations[et.Name] {
+			g.Println(v.String()) // nolint: errcheck
 		}
 		g.Println("}")                                              // nolint: errcheck
 		g.Println()                                                 // nolint: errcheck
@@ -119,20 +155,87 @@ func (g *CodeGen) Gen() *CodeGen {
 		g.Printf("return \"%s\"\n", tableName)                      // nolint: errcheck
 		g.Println("}")                                              // nolint: errcheck
 		g.Println()                                                 // nolint: errcheck
+		for _, enum := range enums[et.Name] {
+			g.genEnum(enum)
+		}
 	}
 	return g
 }
 
-func (g *CodeGen) genModelStructField(field *FieldDescriptor, customFieldIdent map[string]string) string {
+func (g *CodeGen) genEnum(enum *EnumDescriptor) {
+	name := enum.Name
+	g.Printf("// %s enum\n", name)                  // nolint: errcheck
+	g.Printf("type %s %s\n", name, enum.Type.Ident) // nolint: errcheck
+	g.Println()                                     // nolint: errcheck
+	g.Println("const (")                            // nolint: errcheck
+	for _, v := range enum.Values {
+		if v.Comment != "" {
+			g.Printf("%s %s = %s // %s\n", v.Name, name, v.Value, v.Comment) // nolint: errcheck
+		} else {
+			g.Printf("%s %s = %s\n", v.Name, name, v.Value) // nolint: errcheck
+		}
+	}
+	g.Println(")") // nolint: errcheck
+	g.Println()    // nolint: errcheck
+	//* String
+	g.Println("// String implement fmt.Stringer interface") // nolint: errcheck
+	g.Printf("func (v %s) String() string {\n", name)       // nolint: errcheck
+	g.Println("switch v {")                                 // nolint: errcheck
+	for _, v := range enum.Values {
+		label := v.Value // 字符串枚举默认为值本身
+		if v.Comment != "" {
+			label = strconv.Quote(v.Comment)
+		} else if enum.IsInteger() {
+			label = strconv.Quote(strings.TrimPrefix(v.Name, name))
+		}
+		g.Printf("case %s:\n", v.Name) // nolint: errcheck
+		g.Printf("return %s\n", label) // nolint: errcheck
+	}
+	g.Println("}")                                                              // nolint: errcheck
+	g.Printf("return fmt.Sprintf(\"%s(%%v)\", %s(v))\n", name, enum.Type.Ident) // nolint: errcheck
+	g.Println("}")                                                              // nolint: errcheck
+	g.Println()                                                                 // nolint: errcheck
+	//* IsValid
+	g.Println("// IsValid reports whether the value is a defined enum value") // nolint: errcheck
+	g.Printf("func (v %s) IsValid() bool {\n", name)                          // nolint: errcheck
+	g.Println("switch v {")                                                   // nolint: errcheck
+	for _, v := range enum.Values {
+		g.Printf("case %s:\n", v.Name) // nolint: errcheck
+		g.Println("return true")       // nolint: errcheck
+	}
+	g.Println("}")            // nolint: errcheck
+	g.Println("return false") // nolint: errcheck
+	g.Println("}")            // nolint: errcheck
+	g.Println()               // nolint: errcheck
+	//* Scan, Value
+	nullType, field, valuer := "sql.NullString", "String", "string(v)"
+	if enum.IsInteger() {
+		nullType, field, valuer = "sql.NullInt64", "Int64", "int64(v)"
+	}
+	g.Println("// Scan implement sql.Scanner interface")            // nolint: errcheck
+	g.Printf("func (v *%s) Scan(value any) error {\n", name)        // nolint: errcheck
+	g.Printf("var n %s\n", nullType)                                // nolint: errcheck
+	g.Println("if err := n.Scan(value); err != nil {")              // nolint: errcheck
+	g.Println("return err")                                         // nolint: errcheck
+	g.Println("}")                                                  // nolint: errcheck
+	g.Printf("*v = %s(n.%s)\n", name, field)                        // nolint: errcheck
+	g.Println("return nil")                                         // nolint: errcheck
+	g.Println("}")                                                  // nolint: errcheck
+	g.Println()                                                     // nolint: errcheck
+	g.Println("// Value implement driver.Valuer interface")         // nolint: errcheck
+	g.Printf("func (v %s) Value() (driver.Value, error) {\n", name) // nolint: errcheck
+	g.Printf("return %s, nil\n", valuer)                            // nolint: errcheck
+	g.Println("}")                                                  // nolint: errcheck
+	g.Println()                                                     // nolint: errcheck
+}
+
+func (g *CodeGen) genModelStructField(field *FieldDescriptor) string {
 	b := strings.Builder{}
 	b.Grow(128)
 	ident := field.Type.Ident
 	if field.GoPointer && !field.Type.NonPointer {
 		ident = "*" + field.Type.Ident
 	}
-	if customFieldIdent != nil && customFieldIdent[field.ColumnName] != "" {
-		ident = customFieldIdent[field.ColumnName]
-	}
 	// field
 	b.WriteString(field.GoName)
 	b.WriteString(" ")
@@ -149,7 +252,8 @@ func (g *CodeGen) genModelStructField(field *FieldDescriptor, customFieldIdent m
 	return b.String()
 }
 
-func (et *EntityDescriptor) fixEntityField(opt *Option) {
+// fixEntityField fix the entity fields, returns the enums of the entity.
+func (et *EntityDescriptor) fixEntityField(opt *Option) []*EnumDescriptor {
 	if opt == nil {
 		opt = defaultOption()
 	}
@@ -165,44 +269,36 @@ func (et *EntityDescriptor) fixEntityField(opt *Option) {
 		allFieldName[field.GoName] = struct{}{}
 	}
 
+	var enums []*EnumDescriptor
 	for _, field := range et.Fields {
+		values := field.enumValues()
 		field.fixField(allFieldName, escapeNames, opt)
+		if field.CustomType {
+			continue
+		}
+		if enum := field.intoEnum(et.Name, values); enum != nil {
+			enums = append(enums, enum)
+		}
 	}
+	return enums
 }
 
 // 根据规则转义一些数据
 func (field *FieldDescriptor) fixField(allFieldName, escapeFieldNames map[string]struct{}, opt *Option) {
-	if field.ColumnName == "deleted_at" {
-		if field.Type.IsInteger() {
-			field.GoPointer = false
-			field.Type = SoftDeleteType().WithNewType(field.Type.Type)
-		} else if field.Type.IsInteger() {
-			field.GoPointer = false
-			field.Type = GormDeletedAtType().WithNewType(field.Type.Type)
-		}
-	}
 	if opt == nil {
 		opt = defaultOption()
 	}
-	if opt.EnableInt {
-		switch field.Type.Type { // nolint: exhaustive
-		case TypeInt8, TypeInt16, TypeInt32:
-			field.Type = IntType().WithNewType(field.Type.Type)
-		case TypeUint8, TypeUint16, TypeUint32:
-			field.Type = UintType().WithNewType(field.Type.Type)
-		}
-	}
-	if opt.EnableBoolInt && field.Type.IsBool() {
-		field.Type = IntType().WithNewType(field.Type.Type)
-	}
-	if field.Nullable && opt.DisableNullToPoint {
-		gt, ok := getSQLNullValueGoType(field.Type.Type)
-		if ok {
-			field.Type = gt
-			field.GoPointer = false
-		}
+	if !field.CustomType { // 自定义类型原样使用
+		field.applyTypeOption(&opt.TypeOption)
+		field.fixGoType(opt)
 	}
 	for tag, kind := range opt.Tags {
+		if kind == utils.StyleValidator {
+			if vv := field.validatorRules(); vv != "" {
+				field.Tags = append(field.Tags, fmt.Sprintf(`%s:"%s"`, tag, vv))
+			}
+			continue
+		}
 		if tag == "json" {
 			if vv := matcher.JsonTag(field.Comment); vv != "" {
 				field.Tags = append(field.Tags, fmt.Sprintf(`%s:"%s"`, tag, vv))
@@ -227,21 +323,57 @@ func (field *FieldDescriptor) fixField(allFieldName, escapeFieldNames map[string
 		}
 		field.Tags = append(field.Tags, fmt.Sprintf(format, tag, vv))
 	}
-	goName := field.GoName
+	field.GoName = escapeGoName(field.GoName, allFieldName, escapeFieldNames)
+}
+
+// fixGoType fix the go type by the option.
+func (field *FieldDescriptor) fixGoType(opt *Option) {
+	if field.ColumnName == "deleted_at" {
+		if field.Type.IsInteger() {
+			field.GoPointer = false
+			field.Type = SoftDeleteType().WithNewType(field.Type.Type)
+		} else if field.Type.IsInteger() {
+			field.GoPointer = false
+			field.Type = GormDeletedAtType().WithNewType(field.Type.Type)
+		}
+	}
+	if opt.EnableInt {
+		switch field.Type.Type { // nolint: exhaustive
+		case TypeInt8, TypeInt16, TypeInt32:
+			field.Type = IntType().WithNewType(field.Type.Type)
+		case TypeUint8, TypeUint16, TypeUint32:
+			field.Type = UintType().WithNewType(field.Type.Type)
+		}
+	}
+	if opt.EnableBoolInt && field.Type.IsBool() {
+		field.Type = IntType().WithNewType(field.Type.Type)
+	}
+	if field.Nullable && field.GoPointer && opt.DisableNullToPoint {
+		gt, ok := nullValueGoType(field.Type, opt.NullStyle)
+		if ok {
+			field.Type = gt
+			field.GoPointer = false
+		}
+	}
+}
+
+// escapeGoName returns the escaped Go name, the escaped name is added to escapeFieldNames.
+func escapeGoName(goName string, allFieldName, escapeFieldNames map[string]struct{}) string {
+	name := goName
 	for {
-		_, ok := escapeFieldNames[goName]
+		_, ok := escapeFieldNames[name]
 		if !ok { // need to escape
 			break
 		}
-		goName = "X" + goName
+		name = "X" + name
 		// 和当前字段存在的重复, 再追加一个
-		_, ok = allFieldName[goName]
+		_, ok = allFieldName[name]
 		if ok {
-			goName = "X" + goName
+			name = "X" + name
 		}
 	}
-	if field.GoName != goName {
-		field.GoName = goName
-		escapeFieldNames[goName] = struct{}{} // 添加为必须转义
+	if name != goName {
+		escapeFieldNames[name] = struct{}{} // 添加为必须转义
 	}
+	return name
 }
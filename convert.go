@@ -0,0 +1,634 @@
+package ens
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+	"golang.org/x/tools/imports"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/things-go/ens/proto"
+	"github.com/things-go/ens/utils"
+)
+
+const (
+	timestamppbImportPath   = "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspbImportPath    = "google.golang.org/protobuf/types/known/wrapperspb"
+	dateImportPath          = "google.golang.org/genproto/googleapis/type/date"
+	uuidImportPath          = "github.com/google/uuid"
+	googleProtobufTimestamp = "google.protobuf.Timestamp"
+	googleTypeDate          = "google.type.Date"
+)
+
+// ConvertCodeGen generate the nil-safe converters between the gorm models(CodeGen) and the protobuf messages(proto.CodeGen).
+// the model options and proto options must be same as the CodeGen and proto.CodeGen.
+//
+//	func UserToPB(m *model.User) *pb.User
+//	func UserFromPB(p *pb.User) *model.User
+//	func UsersToPB(ms []*model.User) []*pb.User
+//	func UsersFromPB(ps []*pb.User) []*model.User
+type ConvertCodeGen struct {
+	buf               bytes.Buffer
+	Entities          []*EntityDescriptor
+	ByName            string
+	Version           string
+	PackageName       string // 包名
+	ModelImportPath   string // model导入路径, 必填
+	PbImportPath      string // protobuf go导入路径, 必填, 与model不同包(同名类型)
+	DisableDocComment bool   // 禁用doc注释
+	Option                   // model option, same as CodeGen
+	// proto option, same as proto.CodeGen
+	Style            string // 字段代码风格, snakeCase, smallCamelCase, pascalCase
+	DisableBool      bool   // 禁用bool,使用int32
+	DisableTimestamp bool   // 禁用google.protobuf.Timestamp,使用int64(unix second)
+	Nullable         string // 可空字段的默认策略, optional(默认), wrapper, none
+}
+
+// Bytes returns the CodeBuf's buffer.
+func (g *ConvertCodeGen) Bytes() []byte {
+	return g.buf.Bytes()
+}
+
+// FormatSource return formats and adjusts imports contents of the CodeGen's buffer,
+// returns error if the ModelImportPath or PbImportPath is empty or same, the model and the message have the same name.
+func (g *ConvertCodeGen) FormatSource() ([]byte, error) {
+	if g.ModelImportPath == "" || g.PbImportPath == "" {
+		return nil, errors.New("convert: modelImportPath and pbImportPath are required")
+	}
+	if g.ModelImportPath == g.PbImportPath {
+		return nil, errors.New("convert: modelImportPath and pbImportPath must be different packages")
+	}
+	data := g.buf.Bytes()
+	if len(data) == 0 {
+		return data, nil
+	}
+	return imports.Process("", data, &imports.Options{
+		Fragment:   false,
+		AllErrors:  false,
+		Comments:   true,
+		TabIndent:  true,
+		TabWidth:   4,
+		FormatOnly: false,
+	})
+}
+
+// Write appends the contents of p to the buffer,
+func (g *ConvertCodeGen) Write(b []byte) (n int, err error) {
+	return g.buf.Write(b)
+}
+
+// Print formats using the default formats for its operands and writes to the generated output.
+// Spaces are added between operands when neither is a string.
+// It returns the number of bytes written and any write error encountered.
+func (g *ConvertCodeGen) Print(a ...any) (n int, err error) {
+	return fmt.Fprint(&g.buf, a...)
+}
+
+// Printf formats according to a format specifier for its operands and writes to the generated output.
+// It returns the number of bytes written and any write error encountered.
+func (g *ConvertCodeGen) Printf(format string, a ...any) (n int, err error) {
+	return fmt.Fprintf(&g.buf, format, a...)
+}
+
+// Fprintln formats using the default formats to the generated output.
+// Spaces are always added between operands and a newline is appended.
+// It returns the number of bytes written and any write error encountered.
+func (g *ConvertCodeGen) Println(a ...any) (n int, err error) {
+	return fmt.Fprintln(&g.buf, a...)
+}
+
+func (g *ConvertCodeGen) Gen() *ConvertCodeGen {
+	modelQualifier, pbQualifier := "", ""
+	if p := utils.PkgName(g.ModelImportPath); p != "" {
+		modelQualifier = p + "."
+	}
+	if p := utils.PkgName(g.PbImportPath); p != "" {
+		pbQualifier = p + "."
+	}
+	if !g.DisableDocComment {
+		g.Printf("// Code generated by %s. DO NOT EDIT.\n", g.ByName) // nolint: errcheck
+		g.Printf("// version: %s\n", g.Version)                       // nolint: errcheck
+		g.Println()                                                   // nolint: errcheck
+	}
+	g.Printf("package %s\n", g.PackageName) // nolint: errcheck
+	g.Println()                             // nolint: errcheck
+
+	//* 在副本上处理转义, 不修改实体
+	entities := make([]*EntityDescriptor, 0, len(g.Entities))
+	for _, et := range g.Entities {
+		entities = append(entities, et.cloneFields())
+	}
+	for _, et := range entities {
+		et.fixEntityField(&g.Option)
+	}
+	//* import, 未使用的导入由 FormatSource 删除
+	imports := []string{"time", "database/sql", timestamppbImportPath, wrapperspbImportPath, dateImportPath, uuidImportPath}
+	for _, et := range entities {
+		for _, field := range et.Fields {
+			for _, pkgPath := range []string{field.Type.PkgPath, field.Type.ElemPkgPath} {
+				if pkgPath != "" && !slices.Contains(imports, pkgPath) {
+					imports = append(imports, pkgPath)
+				}
+			}
+		}
+	}
+	if modelQualifier != "" {
+		imports = append(imports, g.ModelImportPath)
+	}
+	if pbQualifier != "" && g.PbImportPath != g.ModelImportPath {
+		imports = append(imports, g.PbImportPath)
+	}
+	g.Println("import (") // nolint: errcheck
+	for _, v := range imports {
+		g.Printf("\"%s\"\n", v) // nolint: errcheck
+	}
+	g.Println(")") // nolint: errcheck
+	g.Println()    // nolint: errcheck
+
+	//* 与 proto 相同, 在 schema 上解析引用其它表生成的枚举
+	msgs := (&Schema{Entities: g.Entities}).IntoProto().Entities
+	enums := make([]*proto.Enum, 0, len(msgs))
+	for _, msg := range msgs {
+		enums = append(enums, msg.Enums...)
+	}
+	for i, et := range entities {
+		c := &converter{
+			g:              g,
+			modelQualifier: modelQualifier,
+			pbQualifier:    pbQualifier,
+			origin:         g.Entities[i],
+			entity:         et,
+			msg:            msgs[i],
+			enums:          enums,
+		}
+		c.gen()
+	}
+	return g
+}
+
+// cloneFields returns a shallow copy of the entity with the copied fields.
+func (s *EntityDescriptor) cloneFields() *EntityDescriptor {
+	et := *s
+	et.Fields = make([]*FieldDescriptor, 0, len(s.Fields))
+	for _, field := range s.Fields {
+		f := *field
+		f.Tags = slices.Clone(field.Tags)
+		et.Fields = append(et.Fields, &f)
+	}
+	return &et
+}
+
+type converter struct {
+	g              *ConvertCodeGen
+	modelQualifier string            // model package qualifier, like `model.`
+	pbQualifier    string            // protobuf package qualifier, like `pb.`
+	origin         *EntityDescriptor // the origin entity, not fixed
+	entity         *EntityDescriptor // the fixed entity
+	msg            *proto.Message
+	enums          []*proto.Enum // the generated enums of all the messages
+}
+
+// value kind of the model and protobuf field.
+const (
+	kindUnsupported = iota
+	kindBool
+	kindNumber
+	kindString
+	kindBytes
+	kindTime
+	kindText // model type convert to string with `String()`, and parse back, like decimal.Decimal
+	kindEnum // protobuf enum
+	kindDate // protobuf google.type.Date
+)
+
+// fieldValue the value of the field, excluding the pointer, sql.NullXXX wrapper.
+type fieldValue struct {
+	kind  int
+	ident string // go type identifier of the value, like int64, time.Time, pb.UserStatus
+}
+
+func (c *converter) gen() {
+	name := utils.PascalCase(c.entity.Name)
+	plural := inflection.Plural(name)
+	if plural == name {
+		plural = name + "Slice"
+	}
+	modelType := c.modelQualifier + name
+	pbType := c.pbQualifier + utils.GoCamelCase(name)
+
+	toPB := make([]string, 0, len(c.entity.Fields)*2)
+	fromPB := make([]string, 0, len(c.entity.Fields)*2)
+	for i, field := range c.entity.Fields {
+		mf := c.msg.Fields[i]
+		pbName := utils.GoCamelCase(utils.StyleName(c.g.Style, mf.Name))
+		to, from, ok := c.convertField(c.origin.Fields[i], field, mf, pbName)
+		if !ok {
+			comment := fmt.Sprintf("// %s: unsupported conversion, convert it manually", field.GoName)
+			toPB = append(toPB, comment)
+			fromPB = append(fromPB, comment)
+			continue
+		}
+		toPB = append(toPB, to...)
+		fromPB = append(fromPB, from...)
+	}
+
+	g := c.g
+	g.Printf("// %sToPB convert %s to %s\n", name, modelType, pbType) // nolint: errcheck
+	g.Printf("func %sToPB(m *%s) *%s {\n", name, modelType, pbType)   // nolint: errcheck
+	g.Println("if m == nil {")                                        // nolint: errcheck
+	g.Println("return nil")                                           // nolint: errcheck
+	g.Println("}")                                                    // nolint: errcheck
+	g.Printf("out := &%s{}\n", pbType)                                // nolint: errcheck
+	g.Println(strings.Join(toPB, "\n"))                               // nolint: errcheck
+	g.Println("return out")                                           // nolint: errcheck
+	g.Println("}")                                                    // nolint: errcheck
+	g.Println()                                                       // nolint: errcheck
+
+	g.Printf("// %sFromPB convert %s to %s\n", name, pbType, modelType) // nolint: errcheck
+	g.Printf("func %sFromPB(p *%s) *%s {\n", name, pbType, modelType)   // nolint: errcheck
+	g.Println("if p == nil {")                                          // nolint: errcheck
+	g.Println("return nil")                                             // nolint: errcheck
+	g.Println("}")                                                      // nolint: errcheck
+	g.Printf("out := &%s{}\n", modelType)                               // nolint: errcheck
+	g.Println(strings.Join(fromPB, "\n"))                               // nolint: errcheck
+	g.Println("return out")                                             // nolint: errcheck
+	g.Println("}")                                                      // nolint: errcheck
+	g.Println()                                                         // nolint: errcheck
+
+	for _, v := range [][3]string{
+		{"ToPB", modelType, pbType},
+		{"FromPB", pbType, modelType},
+	} {
+		method, from, to := v[0], v[1], v[2]
+		g.Printf("// %s%s convert []*%s to []*%s\n", plural, method, from, to) // nolint: errcheck
+		g.Printf("func %s%s(vs []*%s) []*%s {\n", plural, method, from, to)    // nolint: errcheck
+		g.Println("if vs == nil {")                                            // nolint: errcheck
+		g.Println("return nil")                                                // nolint: errcheck
+		g.Println("}")                                                         // nolint: errcheck
+		g.Printf("out := make([]*%s, 0, len(vs))\n", to)                       // nolint: errcheck
+		g.Println("for _, v := range vs {")                                    // nolint: errcheck
+		g.Printf("out = append(out, %s%s(v))\n", name, method)                 // nolint: errcheck
+		g.Println("}")                                                         // nolint: errcheck
+		g.Println("return out")                                                // nolint: errcheck
+		g.Println("}")                                                         // nolint: errcheck
+		g.Println()                                                            // nolint: errcheck
+	}
+}
+
+// convertField returns the statements of the field conversion, model to protobuf and protobuf to model.
+func (c *converter) convertField(origin, field *FieldDescriptor, mf *proto.MessageField, pbName string) (toPB, fromPB []string, ok bool) {
+	mv, wrapper := c.modelValue(field)
+	pv, enum := c.pbValue(mf)
+	if mv.kind == kindUnsupported || pv.kind == kindUnsupported {
+		return nil, nil, false
+	}
+	var enumValues []string // the string value of the enum values, the same order as the protobuf enum values.
+	if pv.kind == kindEnum && mv.kind == kindString {
+		for _, v := range origin.enumValues() {
+			enumValues = append(enumValues, v.Value)
+		}
+		if len(enumValues) != len(enum.Values) {
+			return nil, nil, false
+		}
+	}
+	modelPointer := field.GoPointer && !field.Type.NonPointer
+	modelNilable := field.GoPointer && field.Type.NonPointer && field.Nullable // []byte, json.RawMessage
+	pbPointer := mf.Cardinality == protoreflect.Optional && pv.kind != kindBytes && pv.kind != kindTime && pv.kind != kindDate
+	if pv.kind == kindTime && c.g.DisableTimestamp {
+		pbPointer = mf.Cardinality == protoreflect.Optional
+	}
+	pbWrapper := "" // wrapperspb constructor, like Int64
+	switch proto.NullableOf(mf, c.g.Nullable) {
+	case proto.NullableNone:
+		pbPointer = false
+	case proto.NullableWrapper:
+		if v, ok := wrapperConstructors[pv.ident]; ok {
+			pbPointer, pbWrapper = false, v
+		}
+	}
+
+	//* model -> protobuf
+	{
+		src, guard := "m."+field.GoName, ""
+		switch {
+		case wrapper != "":
+			guard, src = src+".Valid", src+"."+wrapper
+		case modelPointer:
+			guard, src = src+" != nil", "*"+src
+		case modelNilable:
+			guard = src + " != nil"
+		}
+		stmts, expr, ok := c.toPB(mv, pv, enum, enumValues, src)
+		if !ok {
+			return nil, nil, false
+		}
+		wrap := ""
+		if pbWrapper != "" {
+			wrap = "wrapperspb." + pbWrapper + "(%s)"
+		}
+		toPB = assignStmts(guard, stmts, "out."+pbName, expr, pbPointer, wrap)
+	}
+	//* protobuf -> model
+	{
+		src, guard := "p."+pbName, ""
+		switch {
+		case pbWrapper != "":
+			guard, src = src+" != nil", src+".GetValue()"
+		case pv.kind == kindTime && !c.g.DisableTimestamp, pv.kind == kindDate:
+			guard = src + " != nil"
+		case pbPointer:
+			guard, src = src+" != nil", "*"+src
+		}
+		stmts, expr, ok := c.fromPB(mv, pv, enum, enumValues, src)
+		if !ok {
+			return nil, nil, false
+		}
+		wrap := ""
+		if wrapper != "" {
+			wrap = field.Type.Ident + "{" + wrapper + ": %s, Valid: true}"
+		}
+		fromPB = assignStmts(guard, stmts, "out."+field.GoName, expr, modelPointer, wrap)
+	}
+	return toPB, fromPB, true
+}
+
+// assignStmts returns the assignment statements, wrapped by the guard if not empty.
+// wrap is the format of the wrapper like `sql.NullInt64{Int64: %s, Valid: true}`, pointer take the address of the value.
+func assignStmts(guard string, stmts []string, dst, expr string, pointer bool, wrap string) []string {
+	body := slices.Clone(stmts)
+	switch {
+	case wrap != "":
+		body = append(body, dst+" = "+fmt.Sprintf(wrap, expr))
+	case pointer:
+		if expr != "v" {
+			body = append(body, "v := "+expr)
+		}
+		body = append(body, dst+" = &v")
+	default:
+		body = append(body, dst+" = "+expr)
+	}
+	if guard != "" {
+		return append(append([]string{"if " + guard + " {"}, body...), "}")
+	}
+	if len(body) > 1 { // 有局部变量时, 使用代码块隔离
+		return append(append([]string{"{"}, body...), "}")
+	}
+	return body
+}
+
+// modelValue returns the value of the model field, and the value field name of the wrapper like sql.NullInt64.
+func (c *converter) modelValue(field *FieldDescriptor) (fieldValue, string) {
+	ident := field.Type.Ident
+	if elem, ok := nullTypeArgument(ident); ok { // sql.Null[T], datatypes.Null[T]
+		v, wrapper := c.modelValue(&FieldDescriptor{
+			Type: GoType{Type: field.Type.Type, Ident: elem, PkgPath: field.Type.ElemPkgPath},
+		})
+		if wrapper != "" {
+			return fieldValue{kind: kindUnsupported}, ""
+		}
+		return v, "V"
+	}
+	switch ident {
+	case "gorm.DeletedAt":
+		return fieldValue{kind: kindTime, ident: "time.Time"}, "Time"
+	case "sql.NullBool":
+		return fieldValue{kind: kindBool, ident: "bool"}, "Bool"
+	case "sql.NullByte":
+		return fieldValue{kind: kindNumber, ident: "byte"}, "Byte"
+	case "sql.NullInt16", "sql.NullInt32", "sql.NullInt64", "sql.NullFloat64":
+		wrapper := strings.TrimPrefix(ident, "sql.Null")
+		return fieldValue{kind: kindNumber, ident: strings.ToLower(wrapper)}, wrapper
+	case "sql.NullString":
+		return fieldValue{kind: kindString, ident: "string"}, "String"
+	case "sql.NullTime":
+		return fieldValue{kind: kindTime, ident: "time.Time"}, "Time"
+	case "time.Time", "datatypes.Date":
+		return fieldValue{kind: kindTime, ident: ident}, ""
+	case "[]byte", "json.RawMessage", "jsontext.Value", "datatypes.JSON":
+		return fieldValue{kind: kindBytes, ident: ident}, ""
+	case "bool":
+		return fieldValue{kind: kindBool, ident: ident}, ""
+	case "datatypes.Time", "datatypes.UUID", "datatypes.BinUUID", "decimal.Decimal":
+		return fieldValue{kind: kindText, ident: ident}, ""
+	case "string":
+		return fieldValue{kind: kindString, ident: ident}, ""
+	}
+	if field.Type.PkgPath == "" && strings.Contains(ident, ".") || strings.Contains(ident, "[") { // custom type, like pkg.Type, null.Value[int64]
+		return fieldValue{kind: kindUnsupported}, ""
+	}
+	if field.Type.PkgPath == "" && !isBuiltinNumber(ident) { // the typed enum in the model package
+		ident = c.modelQualifier + ident
+	}
+	switch {
+	case field.Type.Type.IsNumeric(), field.Type.Type.IsBool() && isBuiltinNumber(field.Type.Ident):
+		return fieldValue{kind: kindNumber, ident: ident}, ""
+	case field.Type.Type == TypeEnum, field.Type.Type == TypeString:
+		return fieldValue{kind: kindString, ident: ident}, ""
+	}
+	return fieldValue{kind: kindUnsupported}, ""
+}
+
+// pbValue returns the value of the protobuf field, and the enum if the field is a generated enum.
+func (c *converter) pbValue(mf *proto.MessageField) (fieldValue, *proto.Enum) {
+	switch mf.Type { // nolint: exhaustive
+	case protoreflect.BoolKind:
+		if c.g.DisableBool {
+			return fieldValue{kind: kindNumber, ident: "int32"}, nil
+		}
+		return fieldValue{kind: kindBool, ident: "bool"}, nil
+	case protoreflect.Int32Kind:
+		return fieldValue{kind: kindNumber, ident: "int32"}, nil
+	case protoreflect.Int64Kind:
+		return fieldValue{kind: kindNumber, ident: "int64"}, nil
+	case protoreflect.Uint32Kind:
+		return fieldValue{kind: kindNumber, ident: "uint32"}, nil
+	case protoreflect.Uint64Kind:
+		return fieldValue{kind: kindNumber, ident: "uint64"}, nil
+	case protoreflect.FloatKind:
+		return fieldValue{kind: kindNumber, ident: "float32"}, nil
+	case protoreflect.DoubleKind:
+		return fieldValue{kind: kindNumber, ident: "float64"}, nil
+	case protoreflect.StringKind:
+		return fieldValue{kind: kindString, ident: "string"}, nil
+	case protoreflect.BytesKind:
+		return fieldValue{kind: kindBytes, ident: "[]byte"}, nil
+	case protoreflect.MessageKind:
+		if mf.TypeName == googleProtobufTimestamp {
+			if c.g.DisableTimestamp {
+				return fieldValue{kind: kindTime, ident: "int64"}, nil
+			}
+			return fieldValue{kind: kindTime, ident: "*timestamppb.Timestamp"}, nil
+		}
+		if mf.TypeName == googleTypeDate {
+			return fieldValue{kind: kindDate, ident: "*date.Date"}, nil
+		}
+	case protoreflect.EnumKind:
+		for _, enum := range c.enums {
+			if enum.Name == mf.TypeName {
+				return fieldValue{kind: kindEnum, ident: c.pbQualifier + utils.GoCamelCase(enum.Name)}, enum
+			}
+		}
+	}
+	return fieldValue{kind: kindUnsupported}, nil
+}
+
+// toPB returns the statements and the expression which convert the model value to the protobuf value.
+func (c *converter) toPB(mv, pv fieldValue, enum *proto.Enum, enumValues []string, src string) ([]string, string, bool) {
+	switch {
+	case mv.kind == kindTime && pv.kind == kindTime:
+		if mv.ident != "time.Time" {
+			src = "time.Time(" + src + ")"
+		}
+		if c.g.DisableTimestamp {
+			return nil, src + ".Unix()", true
+		}
+		return nil, "timestamppb.New(" + src + ")", true
+	case mv.kind == kindTime && pv.kind == kindDate:
+		if mv.ident != "time.Time" {
+			src = "time.Time(" + src + ")"
+		}
+		return []string{"t := " + src}, "&date.Date{Year: int32(t.Year()), Month: int32(t.Month()), Day: int32(t.Day())}", true
+	case mv.kind == kindText && pv.kind == kindString:
+		if strings.HasPrefix(src, "*") {
+			src = "(" + src + ")"
+		}
+		return nil, src + ".String()", true
+	case mv.kind == kindBool && pv.kind == kindBool,
+		mv.kind == kindString && pv.kind == kindString && mv.ident == "string":
+		return nil, src, true
+	case mv.kind == kindBool && pv.kind == kindNumber:
+		return boolToNumber(pv.ident, src), "v", true
+	case mv.kind == kindNumber && pv.kind == kindBool:
+		return nil, src + " != 0", true
+	case mv.kind == kindNumber && (pv.kind == kindNumber || pv.kind == kindEnum),
+		mv.kind == kindString && pv.kind == kindString,
+		mv.kind == kindBytes && pv.kind == kindString:
+		return nil, convertExpr(mv.ident, pv.ident, src), true
+	case mv.kind == kindBytes && pv.kind == kindBytes:
+		return nil, convertExpr(mv.ident, pv.ident, src), true
+	case mv.kind == kindString && pv.kind == kindEnum:
+		stmts := []string{"var v " + pv.ident, "switch " + src + " {"}
+		for i, v := range enum.Values {
+			stmts = append(stmts,
+				"case "+strconv.Quote(enumValues[i])+":",
+				"v = "+pv.ident+"_"+v.Name,
+			)
+		}
+		stmts = append(stmts, "}")
+		return stmts, "v", true
+	}
+	return nil, "", false
+}
+
+// fromPB returns the statements and the expression which convert the protobuf value to the model value.
+func (c *converter) fromPB(mv, pv fieldValue, enum *proto.Enum, enumValues []string, src string) ([]string, string, bool) {
+	switch {
+	case mv.kind == kindTime && pv.kind == kindTime:
+		expr := src + ".AsTime()"
+		if c.g.DisableTimestamp {
+			expr = "time.Unix(" + src + ", 0)"
+		}
+		if mv.ident != "time.Time" {
+			expr = mv.ident + "(" + expr + ")"
+		}
+		return nil, expr, true
+	case mv.kind == kindTime && pv.kind == kindDate:
+		expr := fmt.Sprintf("time.Date(int(%[1]s.GetYear()), time.Month(%[1]s.GetMonth()), int(%[1]s.GetDay()), 0, 0, 0, 0, time.UTC)", src)
+		if mv.ident != "time.Time" {
+			expr = mv.ident + "(" + expr + ")"
+		}
+		return nil, expr, true
+	case mv.kind == kindText && pv.kind == kindString:
+		stmts := make([]string, 0, 2)
+		for _, v := range textParsers[mv.ident] {
+			stmts = append(stmts, strings.ReplaceAll(v, "%s", src))
+		}
+		return stmts, "v", true
+	case mv.kind == kindBool && pv.kind == kindBool,
+		mv.kind == kindString && pv.kind == kindString && mv.ident == "string":
+		return nil, src, true
+	case mv.kind == kindBool && pv.kind == kindNumber:
+		return nil, src + " != 0", true
+	case mv.kind == kindNumber && pv.kind == kindBool:
+		return boolToNumber(mv.ident, src), "v", true
+	case mv.kind == kindNumber && (pv.kind == kindNumber || pv.kind == kindEnum),
+		mv.kind == kindString && pv.kind == kindString,
+		mv.kind == kindBytes && (pv.kind == kindString || pv.kind == kindBytes):
+		return nil, convertExpr(pv.ident, mv.ident, src), true
+	case mv.kind == kindString && pv.kind == kindEnum:
+		stmts := []string{"var v " + mv.ident, "switch " + src + " {"}
+		for i, v := range enum.Values {
+			stmts = append(stmts,
+				"case "+pv.ident+"_"+v.Name+":",
+				"v = "+strconv.Quote(enumValues[i]),
+			)
+		}
+		stmts = append(stmts, "}")
+		return stmts, "v", true
+	}
+	return nil, "", false
+}
+
+func boolToNumber(ident, src string) []string {
+	return []string{
+		"var v " + ident,
+		"if " + src + " {",
+		"v = 1",
+		"}",
+	}
+}
+
+// convertExpr returns the type conversion expression, returns src if the types are the same.
+func convertExpr(from, to, src string) string {
+	if from == to {
+		return src
+	}
+	return to + "(" + src + ")"
+}
+
+// textParsers the statements parse the protobuf string `%s` into the model value `v` of the kindText,
+// the parse error is ignored, the value is zero like the unknown enum.
+var textParsers = map[string][]string{
+	"datatypes.Time":    {"var v datatypes.Time", "_ = v.Scan(%s)"},
+	"datatypes.UUID":    {"u, _ := uuid.Parse(%s)", "v := datatypes.UUID(u)"},
+	"datatypes.BinUUID": {"u, _ := uuid.Parse(%s)", "v := datatypes.BinUUID(u)"},
+	"decimal.Decimal":   {"v, _ := decimal.NewFromString(%s)"},
+}
+
+// wrapperConstructors the go type of the protobuf scalar to the wrapperspb constructor.
+var wrapperConstructors = map[string]string{
+	"bool":    "Bool",
+	"int32":   "Int32",
+	"int64":   "Int64",
+	"uint32":  "UInt32",
+	"uint64":  "UInt64",
+	"float32": "Float",
+	"float64": "Double",
+	"string":  "String",
+	"[]byte":  "Bytes",
+}
+
+func isBuiltinNumber(ident string) bool {
+	switch ident {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "byte",
+		"float32", "float64":
+		return true
+	}
+	return false
+}
+
+// nullTypeArgument returns the type argument of the generic nullable wrapper, like `int64` in `sql.Null[int64]`.
+func nullTypeArgument(ident string) (string, bool) {
+	for _, prefix := range []string{"sql.Null[", "datatypes.Null["} {
+		if v, ok := strings.CutPrefix(ident, prefix); ok && strings.HasSuffix(v, "]") {
+			return v[:len(v)-1], true
+		}
+	}
+	return "", false
+}
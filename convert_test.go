@@ -0,0 +1,203 @@
+package ens_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/proto"
+)
+
+const convertTestSQL = "CREATE TABLE `user` (\n" +
+	"  `id` bigint NOT NULL AUTO_INCREMENT,\n" +
+	"  `name` varchar(64) NOT NULL,\n" +
+	"  `nickname` varchar(64) DEFAULT NULL,\n" +
+	"  `age` tinyint unsigned NOT NULL,\n" +
+	"  `score` double DEFAULT NULL,\n" +
+	"  `enabled` tinyint(1) NOT NULL,\n" +
+	"  `sex` tinyint NOT NULL COMMENT '性别 [@enum: 1:Male:男,2:Female:女]',\n" +
+	"  `status` enum('active','in_progress') NOT NULL,\n" +
+	"  `extra` json DEFAULT NULL,\n" +
+	"  `birthday` datetime DEFAULT NULL,\n" +
+	"  `created_at` datetime NOT NULL,\n" +
+	"  PRIMARY KEY (`id`)\n" +
+	") ENGINE=InnoDB;"
+
+func Test_Convert(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		g     *ens.ConvertCodeGen
+		wants []string
+	}{
+		{
+			"default",
+			&ens.ConvertCodeGen{},
+			[]string{
+				"func UserToPB(m *model.User) *pb.User { if m == nil { return nil } out := &pb.User{}",
+				"out.Id = m.Id",
+				"if m.Nickname != nil { v := *m.Nickname out.Nickname = &v }",
+				"out.Age = uint32(m.Age)",
+				"out.Enabled = m.Enabled",
+				"out.Sex = pb.UserSex(m.Sex)",
+				`{ var v pb.UserStatus switch m.Status { case "active": v = pb.UserStatus_USER_STATUS_ACTIVE case "in_progress": v = pb.UserStatus_USER_STATUS_IN_PROGRESS } out.Status = v }`,
+				"if m.Extra != nil { v := string(m.Extra) out.Extra = &v }",
+				"if m.Birthday != nil { out.Birthday = timestamppb.New(*m.Birthday) }",
+				"out.CreatedAt = timestamppb.New(m.CreatedAt)",
+				"func UserFromPB(p *pb.User) *model.User { if p == nil { return nil } out := &model.User{}",
+				"out.Age = uint8(p.Age)",
+				"out.Sex = model.UserSex(p.Sex)",
+				`{ var v model.UserStatus switch p.Status { case pb.UserStatus_USER_STATUS_ACTIVE: v = "active" case pb.UserStatus_USER_STATUS_IN_PROGRESS: v = "in_progress" } out.Status = v }`,
+				"if p.Extra != nil { out.Extra = " + ens.JSONRawMessageType().Ident + "(*p.Extra) }",
+				"if p.Birthday != nil { v := p.Birthday.AsTime() out.Birthday = &v }",
+				"if p.CreatedAt != nil { out.CreatedAt = p.CreatedAt.AsTime() }",
+				"func UsersToPB(vs []*model.User) []*pb.User { if vs == nil { return nil } out := make([]*pb.User, 0, len(vs)) for _, v := range vs { out = append(out, UserToPB(v)) } return out }",
+				"func UsersFromPB(vs []*pb.User) []*model.User {",
+			},
+		},
+		{
+			"null and disable",
+			&ens.ConvertCodeGen{
+				Option:           ens.Option{DisableNullToPoint: true},
+				DisableBool:      true,
+				DisableTimestamp: true,
+			},
+			[]string{
+				"if m.Nickname.Valid { v := m.Nickname.String out.Nickname = &v }",
+				"if p.Nickname != nil { out.Nickname = sql.NullString{String: *p.Nickname, Valid: true} }",
+				"if m.Score.Valid { v := m.Score.Float64 out.Score = &v }",
+				"{ var v int32 if m.Enabled { v = 1 } out.Enabled = v }",
+				"out.Enabled = p.Enabled != 0",
+				"if m.Birthday.Valid { v := m.Birthday.Time.Unix() out.Birthday = &v }",
+				"if p.Birthday != nil { out.Birthday = sql.NullTime{Time: time.Unix(*p.Birthday, 0), Valid: true} }",
+				"out.CreatedAt = m.CreatedAt.Unix()",
+				"out.CreatedAt = time.Unix(p.CreatedAt, 0)",
+			},
+		},
+		{
+			"generic null",
+			&ens.ConvertCodeGen{
+				Option: ens.Option{DisableNullToPoint: true, NullStyle: ens.NullStyleGeneric},
+			},
+			[]string{
+				"if m.Nickname.Valid { v := m.Nickname.V out.Nickname = &v }",
+				"if p.Nickname != nil { out.Nickname = sql.Null[string]{V: *p.Nickname, Valid: true} }",
+				"if m.Extra.Valid { v := string(m.Extra.V) out.Extra = &v }",
+				"if m.Birthday.Valid { out.Birthday = timestamppb.New(m.Birthday.V) }",
+				"if p.Birthday != nil { out.Birthday = sql.Null[time.Time]{V: p.Birthday.AsTime(), Valid: true} }",
+			},
+		},
+		{
+			"wrapper",
+			&ens.ConvertCodeGen{
+				Nullable: proto.NullableWrapper,
+			},
+			[]string{
+				"if m.Nickname != nil { out.Nickname = wrapperspb.String(*m.Nickname) }",
+				"if p.Nickname != nil { v := p.Nickname.GetValue() out.Nickname = &v }",
+				"if m.Extra != nil { out.Extra = wrapperspb.String(string(m.Extra)) }",
+				"if m.Birthday != nil { out.Birthday = timestamppb.New(*m.Birthday) }",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g := tt.g
+			g.Entities = parseSQL(t, convertTestSQL).Entities
+			g.ByName = "ormat"
+			g.Version = "v1.0.0"
+			g.PackageName = "convert"
+			g.ModelImportPath = "example.com/model"
+			g.PbImportPath = "example.com/api/pb"
+			data, err := g.Gen().FormatSource()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := strings.Join(strings.Fields(string(data)), " ")
+			for _, want := range tt.wants {
+				if !strings.Contains(got, want) {
+					t.Errorf("want %q in\n%s", want, data)
+				}
+			}
+		})
+	}
+}
+
+func Test_Convert_ImportPath(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		modelImportPath string
+		pbImportPath    string
+	}{
+		{"empty", "", ""},
+		{"empty pb", "example.com/model", ""},
+		{"same package", "example.com/model", "example.com/model"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &ens.ConvertCodeGen{
+				Entities:        parseSQL(t, convertTestSQL).Entities,
+				PackageName:     "convert",
+				ModelImportPath: tt.modelImportPath,
+				PbImportPath:    tt.pbImportPath,
+			}
+			if _, err := g.Gen().FormatSource(); err == nil {
+				t.Error("want error of the invalid import path")
+			}
+		})
+	}
+}
+
+func Test_Convert_Datatypes(t *testing.T) {
+	sc := parseSQL(t, "CREATE TABLE `order` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `amount` decimal(10,2) NOT NULL,\n"+
+		"  `discount` decimal(10,2) DEFAULT NULL,\n"+
+		"  `pay_date` date NOT NULL,\n"+
+		"  `ship_date` date DEFAULT NULL,\n"+
+		"  `pay_time` time NOT NULL,\n"+
+		"  `trace_id` char(36) NOT NULL,\n"+
+		"  `bin_id` binary(16) DEFAULT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+	sc.ApplyTypeOption(&ens.TypeOption{
+		EnableDatatypesDate: true,
+		EnableDatatypesTime: true,
+		EnableDecimal:       true,
+		EnableUUID:          true,
+	})
+	g := &ens.ConvertCodeGen{
+		Entities:        sc.Entities,
+		ByName:          "ormat",
+		Version:         "v1.0.0",
+		PackageName:     "convert",
+		ModelImportPath: "example.com/model",
+		PbImportPath:    "example.com/api/pb",
+	}
+	data, err := g.Gen().FormatSource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(strings.Fields(string(data)), " ")
+	for _, want := range []string{
+		`"github.com/google/uuid"`,
+		`"github.com/shopspring/decimal"`,
+		`"google.golang.org/genproto/googleapis/type/date"`,
+		"out.Amount = m.Amount.String()",
+		"{ v, _ := decimal.NewFromString(p.Amount) out.Amount = v }",
+		"if m.Discount != nil { v := (*m.Discount).String() out.Discount = &v }",
+		"if p.Discount != nil { v, _ := decimal.NewFromString(*p.Discount) out.Discount = &v }",
+		"{ t := time.Time(m.PayDate) out.PayDate = &date.Date{Year: int32(t.Year()), Month: int32(t.Month()), Day: int32(t.Day())} }",
+		"if p.PayDate != nil { out.PayDate = datatypes.Date(time.Date(int(p.PayDate.GetYear()), time.Month(p.PayDate.GetMonth()), int(p.PayDate.GetDay()), 0, 0, 0, 0, time.UTC)) }",
+		"if m.ShipDate != nil { t := time.Time(*m.ShipDate) out.ShipDate = &date.Date{",
+		"out.PayTime = m.PayTime.String()",
+		"{ var v datatypes.Time _ = v.Scan(p.PayTime) out.PayTime = v }",
+		"out.TraceId = m.TraceId.String()",
+		"{ u, _ := uuid.Parse(p.TraceId) v := datatypes.UUID(u) out.TraceId = v }",
+		"if p.BinId != nil { u, _ := uuid.Parse(*p.BinId) v := datatypes.BinUUID(u) out.BinId = &v }",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, data)
+		}
+	}
+	if strings.Contains(got, "unsupported conversion") {
+		t.Errorf("want all fields converted, got:\n%s", data)
+	}
+}
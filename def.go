@@ -2,12 +2,25 @@ package ens
 
 import "ariga.io/atlas/sql/schema"
 
+// the sql dialect of the table definition.
+const (
+	DialectMySQL    = "mysql"
+	DialectPostgres = "postgres"
+	DialectSQLite   = "sqlite"
+)
+
 type TableDef interface {
 	Table() *schema.Table
 	Definition() string
 	PrimaryKey() IndexDef
 }
 
+// Dialecter the optional interface of TableDef, reports the sql dialect of the table definition,
+// mysql if not implemented.
+type Dialecter interface {
+	Dialect() string
+}
+
 type ColumnDef interface {
 	Column() *schema.Column
 	Definition() string
@@ -0,0 +1,209 @@
+package ens
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlite"
+
+	"github.com/things-go/ens/internal/insql"
+	"github.com/things-go/ens/sqlx"
+)
+
+// Diff compare two schemas, returns the migration from `from` to `to`.
+// Up migrate `from` to `to`, Down is the reverse, the statements are the dialect of the table definitions,
+// the two schemas must be the same dialect, mysql if both are empty or the table definition not implement Dialecter,
+// the column types and defaults are normalized, so the schemas inspected by the different drivers can be compared.
+// nil schema means an empty schema, the entity without table definition is ignored.
+func Diff(from, to *Schema) (*sqlx.Migration, error) {
+	dialect, err := diffDialect(from, to)
+	if err != nil {
+		return nil, err
+	}
+	d, ok := differs[dialect]
+	if !ok {
+		return nil, fmt.Errorf("diff: unsupported dialect %q", dialect)
+	}
+	up, err := d.statements(from, to)
+	if err != nil {
+		return nil, err
+	}
+	down, err := d.statements(to, from)
+	if err != nil {
+		return nil, err
+	}
+	name := ""
+	if to != nil {
+		name = to.Name
+	}
+	return &sqlx.Migration{
+		Name: name,
+		Up:   up,
+		Down: down,
+	}, nil
+}
+
+// diffDialect returns the dialect of the table definitions in the schemas.
+func diffDialect(schemas ...*Schema) (string, error) {
+	dialect := ""
+	for _, sc := range schemas {
+		if sc == nil {
+			continue
+		}
+		for _, entity := range sc.Entities {
+			if entity.Table == nil {
+				continue
+			}
+			v := DialectMySQL
+			if d, ok := entity.Table.(Dialecter); ok {
+				v = d.Dialect()
+			}
+			if dialect != "" && v != dialect {
+				return "", fmt.Errorf("diff: mismatched dialect %s and %s", dialect, v)
+			}
+			dialect = v
+		}
+	}
+	if dialect == "" {
+		dialect = DialectMySQL
+	}
+	return dialect, nil
+}
+
+// differ the differ, planner and type converter of the dialect.
+type differ struct {
+	Dialect    string
+	Diff       schema.Differ
+	Plan       migrate.PlanApplier
+	FormatType func(schema.Type) (string, error)
+	ParseType  func(string) (schema.Type, error)
+}
+
+var differs = map[string]*differ{
+	DialectMySQL:    {DialectMySQL, mysql.DefaultDiff, mysql.DefaultPlan, mysql.FormatType, mysql.ParseType},
+	DialectPostgres: {DialectPostgres, postgres.DefaultDiff, postgres.DefaultPlan, postgres.FormatType, postgres.ParseType},
+	DialectSQLite:   {DialectSQLite, sqlite.DefaultDiff, sqlite.DefaultPlan, sqlite.FormatType, sqlite.ParseType},
+}
+
+func (d *differ) statements(from, to *Schema) ([]string, error) {
+	changes, err := d.Diff.SchemaDiff(d.intoAtlas(from), d.intoAtlas(to))
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	plan, err := d.Plan.PlanChanges(context.Background(), "diff", changes, func(o *migrate.PlanOptions) {
+		o.SchemaQualifier = new(string) // 不需要 schema 前缀
+	})
+	if err != nil {
+		return nil, err
+	}
+	stmts := make([]string, 0, len(plan.Changes))
+	for _, c := range plan.Changes {
+		stmts = append(stmts, c.Cmd)
+	}
+	return stmts, nil
+}
+
+// intoAtlas the schema name is ignored, so the two schemas can be compared.
+// the column types and defaults are normalized, the drivers parse the same column differently,
+// like `varbinary(1024)` as string or binary type, `CURRENT_TIMESTAMP` as literal or raw expression.
+// NOTE: the tables and columns are shallow copied and attached to the new schema, avoid modifying the origin tables.
+func (d *differ) intoAtlas(s *Schema) *schema.Schema {
+	sc := &schema.Schema{}
+	if s == nil {
+		return sc
+	}
+	sc.Tables = make([]*schema.Table, 0, len(s.Entities))
+	for _, entity := range s.Entities {
+		if entity.Table == nil {
+			continue
+		}
+		tb := *entity.Table.Table()
+		tb.Schema = sc
+		// some drivers include the primary key in indexes.
+		tb.Indexes = slices.DeleteFunc(slices.Clone(tb.Indexes), func(v *schema.Index) bool {
+			return insql.IndexEqual(tb.PrimaryKey, v)
+		})
+		columns := make([]*schema.Column, 0, len(tb.Columns))
+		for _, col := range tb.Columns {
+			columns = append(columns, d.normalizeColumn(col))
+		}
+		tb.Columns = columns
+		sc.Tables = append(sc.Tables, &tb)
+	}
+	return sc
+}
+
+func (d *differ) normalizeColumn(col *schema.Column) *schema.Column {
+	c := *col
+	if col.Type != nil {
+		ct := *col.Type
+		if ct.Type != nil {
+			// some drivers parse the binary as string type, the size is lost when formatting.
+			if t, ok := ct.Type.(*schema.StringType); ok && d.Dialect == DialectMySQL &&
+				(strings.EqualFold(t.T, mysql.TypeBinary) || strings.EqualFold(t.T, mysql.TypeVarBinary)) {
+				ct.Type = &schema.BinaryType{T: strings.ToLower(t.T), Size: &t.Size}
+			}
+			if raw, err := d.FormatType(ct.Type); err == nil {
+				if t, err := d.ParseType(raw); err == nil {
+					ct.Type = t
+				}
+			}
+			// the time type only has the fractional seconds precision.
+			if t, ok := ct.Type.(*schema.TimeType); ok {
+				tt := *t
+				if tt.Precision != nil && *tt.Precision == 0 {
+					tt.Precision = nil
+				}
+				tt.Scale = nil
+				ct.Type = &tt
+			}
+		}
+		c.Type = &ct
+	}
+	if d.Dialect == DialectMySQL {
+		c.Default = normalizeCurrentTimestamp(col.Default)
+		c.Attrs = slices.Clone(col.Attrs)
+		for i, attr := range c.Attrs {
+			if v, ok := attr.(*mysql.OnUpdate); ok {
+				if x, ok := normalizeCurrentTimestamp(&schema.RawExpr{X: v.A}).(*schema.RawExpr); ok {
+					c.Attrs[i] = &mysql.OnUpdate{A: x.X}
+				}
+			}
+		}
+	}
+	return &c
+}
+
+var currentTimestampRegexp = regexp.MustCompile(`(?i)^(?:current_timestamp|now|localtime|localtimestamp)(?:[(]\s*(\d*)\s*[)])?$`)
+
+// normalizeCurrentTimestamp normalize the mysql `CURRENT_TIMESTAMP`, `current_timestamp()`, `now()` default
+// to the raw expression `CURRENT_TIMESTAMP`, `CURRENT_TIMESTAMP(3)` if with precision.
+func normalizeCurrentTimestamp(x schema.Expr) schema.Expr {
+	var v string
+	switch x := x.(type) {
+	case *schema.Literal:
+		v = x.V
+	case *schema.RawExpr:
+		v = x.X
+	default:
+		return x
+	}
+	matches := currentTimestampRegexp.FindStringSubmatch(strings.TrimSpace(v))
+	if matches == nil {
+		return x
+	}
+	if p := strings.TrimLeft(matches[1], "0"); p != "" {
+		return &schema.RawExpr{X: "CURRENT_TIMESTAMP(" + p + ")"}
+	}
+	return &schema.RawExpr{X: "CURRENT_TIMESTAMP"}
+}
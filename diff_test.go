@@ -0,0 +1,195 @@
+package ens_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	atlaspostgres "ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+	"github.com/things-go/ens/driver/mysql"
+	"github.com/things-go/ens/driver/postgres"
+	"github.com/things-go/ens/driver/sqlite"
+)
+
+func parseSQL(t *testing.T, data string) *ens.Schema {
+	t.Helper()
+	sc, err := (&mysql.SQL{}).InspectSchema(context.Background(), &driver.InspectOption{Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sc
+}
+
+func Test_Diff(t *testing.T) {
+	from := parseSQL(t, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `name` varchar(64) NOT NULL DEFAULT '',\n"+
+		"  `age` int NOT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n"+
+		"CREATE TABLE `legacy` (\n"+
+		"  `id` bigint NOT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+	to := parseSQL(t, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `name` varchar(128) NOT NULL DEFAULT '',\n"+
+		"  `email` varchar(128) DEFAULT NULL,\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  UNIQUE KEY `uk_email` (`email`)\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n"+
+		"CREATE TABLE `post` (\n"+
+		"  `id` bigint NOT NULL,\n"+
+		"  `title` varchar(255) NOT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+
+	got, err := ens.Diff(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantUp := []string{
+		"ALTER TABLE `user` MODIFY COLUMN `name` varchar(128) NOT NULL DEFAULT \"\", DROP COLUMN `age`, ADD COLUMN `email` varchar(128) NULL, ADD UNIQUE INDEX `uk_email` (`email`)",
+		"CREATE TABLE `post` (`id` bigint NOT NULL, `title` varchar(255) NOT NULL, PRIMARY KEY (`id`)) ENGINE InnoDB CHARSET utf8mb4",
+		"DROP TABLE `legacy`",
+	}
+	wantDown := []string{
+		"ALTER TABLE `user` MODIFY COLUMN `name` varchar(64) NOT NULL DEFAULT \"\", DROP COLUMN `email`, ADD COLUMN `age` int NOT NULL",
+		"CREATE TABLE `legacy` (`id` bigint NOT NULL, PRIMARY KEY (`id`)) ENGINE InnoDB CHARSET utf8mb4",
+		"DROP TABLE `post`",
+	}
+	if !slices.Equal(got.Up, wantUp) {
+		t.Errorf("up = %q, want %q", got.Up, wantUp)
+	}
+	if !slices.Equal(got.Down, wantDown) {
+		t.Errorf("down = %q, want %q", got.Down, wantDown)
+	}
+	// the origin tables should not be modified.
+	if tb := from.Entities[0].Table.Table(); tb.Schema != nil || len(tb.Indexes) != 1 {
+		t.Errorf("origin table should not be modified")
+	}
+
+	same, err := ens.Diff(to, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(same.Up) != 0 || len(same.Down) != 0 {
+		t.Errorf("same schema should be no changes, got: %v, %v", same.Up, same.Down)
+	}
+}
+
+func Test_Diff_Postgres(t *testing.T) {
+	newSchema := func(withEmail bool) *ens.Schema {
+		users := schema.NewTable("users").
+			AddColumns(
+				schema.NewColumn("id").SetType(&schema.IntegerType{T: atlaspostgres.TypeBigInt}),
+				schema.NewColumn("name").SetType(&schema.StringType{T: atlaspostgres.TypeVarChar, Size: 64}),
+			)
+		if withEmail {
+			users.AddColumns(schema.NewNullColumn("email").SetType(&schema.StringType{T: atlaspostgres.TypeVarChar, Size: 128}))
+		}
+		users.SetPrimaryKey(schema.NewPrimaryKey(users.Columns[0]))
+		return &ens.Schema{
+			Entities: []*ens.EntityDescriptor{{Name: "users", Table: postgres.NewTableDef(users)}},
+		}
+	}
+	got, err := ens.Diff(newSchema(false), newSchema(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantUp := []string{`ALTER TABLE "users" ADD COLUMN "email" character varying(128) NULL`}
+	wantDown := []string{`ALTER TABLE "users" DROP COLUMN "email"`}
+	if !slices.Equal(got.Up, wantUp) {
+		t.Errorf("up = %q, want %q", got.Up, wantUp)
+	}
+	if !slices.Equal(got.Down, wantDown) {
+		t.Errorf("down = %q, want %q", got.Down, wantDown)
+	}
+}
+
+func Test_Diff_SQLite(t *testing.T) {
+	posts := schema.NewTable("posts").
+		AddColumns(
+			schema.NewColumn("id").SetType(&schema.IntegerType{T: "integer"}),
+			schema.NewColumn("title").SetType(&schema.StringType{T: "text"}),
+		)
+	posts.SetPrimaryKey(schema.NewPrimaryKey(posts.Columns[0]))
+	to := &ens.Schema{
+		Entities: []*ens.EntityDescriptor{{Name: "posts", Table: sqlite.NewTableDef(posts)}},
+	}
+	got, err := ens.Diff(nil, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantUp := []string{"CREATE TABLE `posts` (`id` integer NOT NULL, `title` text NOT NULL, PRIMARY KEY (`id`))"}
+	wantDown := []string{"PRAGMA foreign_keys = off", "DROP TABLE `posts`", "PRAGMA foreign_keys = on"}
+	if !slices.Equal(got.Up, wantUp) {
+		t.Errorf("up = %q, want %q", got.Up, wantUp)
+	}
+	if !slices.Equal(got.Down, wantDown) {
+		t.Errorf("down = %q, want %q", got.Down, wantDown)
+	}
+
+	// the mismatched dialect.
+	from := parseSQL(t, "CREATE TABLE `posts` (\n"+
+		"  `id` bigint NOT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+	if _, err = ens.Diff(from, to); err == nil {
+		t.Error("want error of the mismatched dialect")
+	}
+}
+
+func Test_Diff_Normalize(t *testing.T) {
+	ddl := "CREATE TABLE `user` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(64) NOT NULL DEFAULT '' COMMENT '名称',\n" +
+		"  `code` char(8) NOT NULL DEFAULT '0',\n" +
+		"  `age` int NOT NULL DEFAULT 0,\n" +
+		"  `status` tinyint NOT NULL DEFAULT 1,\n" +
+		"  `balance` decimal(10,2) NOT NULL DEFAULT '0.00',\n" +
+		"  `ratio` double DEFAULT NULL,\n" +
+		"  `kind` enum('a','b') NOT NULL DEFAULT 'a',\n" +
+		"  `avatar` varbinary(1024) DEFAULT NULL,\n" +
+		"  `content` text,\n" +
+		"  `data` blob,\n" +
+		"  `profile` json DEFAULT NULL,\n" +
+		"  `birthday` date DEFAULT NULL,\n" +
+		"  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+		"  `updated_at` timestamp(3) NULL DEFAULT NULL,\n" +
+		"  `deleted_at` datetime(3) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_name` (`name`),\n" +
+		"  KEY `idx_age_status` (`age`,`status`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;"
+	inspect := func(d driver.Driver) *ens.Schema {
+		sc, err := d.InspectSchema(context.Background(), &driver.InspectOption{Data: ddl})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sc
+	}
+	sqlSchema, tidbSchema := inspect(&mysql.SQL{}), inspect(&mysql.SQLTidb{})
+
+	got, err := ens.Diff(sqlSchema, tidbSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Up) != 0 || len(got.Down) != 0 {
+		t.Errorf("same schema across drivers should be no changes, got: %q, %q", got.Up, got.Down)
+	}
+	// the default of function is not quoted.
+	got, err = ens.Diff(nil, tidbSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Up) != 1 || !strings.Contains(got.Up[0], "`created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,") ||
+		!strings.Contains(got.Up[0], "`deleted_at` datetime(3) NULL") {
+		t.Errorf("unexpected up: %q", got.Up)
+	}
+}
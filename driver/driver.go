@@ -14,6 +14,11 @@ const (
 	Mysql         = "mysql"
 	FileMysql     = "file+mysql"
 	FileMysqlTidb = "file+tidb"
+	MigrateMysql  = "migrate+mysql"
+	Postgres      = "postgres"
+	Sqlite        = "sqlite"
+	FileSqlite    = "file+sqlite"
+	GoModel       = "gomodel"
 )
 
 var drivers sync.Map
@@ -0,0 +1,11 @@
+package gomodel
+
+import (
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+)
+
+func init() {
+	driver.RegisterDriver(driver.GoModel, &GoModel{})
+	ens.RegisterModelParser(parseModel)
+}
@@ -0,0 +1,440 @@
+package gomodel
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	gormschema "gorm.io/gorm/schema"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+	"github.com/things-go/ens/internal/insql"
+	"github.com/things-go/ens/utils"
+)
+
+var _ driver.Driver = (*GoModel)(nil)
+
+var namer = gormschema.NamingStrategy{}
+
+// GoModel inspect the schema from go model structs, url like `gomodel://./internal/model`.
+// the package is loaded with go/packages and go/types, all exported struct are treated as model
+// except the struct only embedded in the other structs(like the common fields) without `TableName()`,
+// table name from `TableName() string` method which return a string literal or gorm naming strategy,
+// column from gorm tag.
+type GoModel struct{}
+
+// InspectSchema implements driver.Driver.
+func (g *GoModel) InspectSchema(_ context.Context, arg *driver.InspectOption) (*ens.Schema, error) {
+	pattern := strings.TrimPrefix(arg.URL, driver.GoModel+"://")
+	models, err := loadModels(pattern)
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]*ens.EntityDescriptor, 0, len(models))
+	for _, m := range models {
+		if !insql.MatchTable(m.TableName, arg.Tables, arg.Exclude) {
+			continue
+		}
+		entity, err := intoSchema(m)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+	return &ens.Schema{
+		Name:     "",
+		Entities: entities,
+	}, nil
+}
+
+// model a go struct model.
+type model struct {
+	Name      string   // struct name
+	TableName string   // table name
+	Comment   string   // struct comment
+	Fields    []*field // fields, embedded struct expanded
+}
+
+// field a go struct field.
+type field struct {
+	Name     string     // go field name
+	Prefix   string     // column name prefix, from embeddedPrefix
+	Type     ens.GoType // go type, not include the pointer
+	Pointer  bool       // go field is pointer.
+	Nullable bool       // go type can hold null, like sql.NullString, gorm.DeletedAt.
+	Tag      string     // gorm tag
+	Comment  string     // field comment
+}
+
+func loadModels(pattern string) ([]*model, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+	}
+	pkgs, err := packages.Load(cfg, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, errors.New("no package found")
+	}
+	models := make([]*model, 0, 64)
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, pkg.Errors[0]
+		}
+		tableNames := tableNameMethods(pkg.Syntax)
+		comments := fieldComments(pkg.Syntax)
+		embeddeds := embeddedNames(pkg.Types)
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					if _, ok := ts.Type.(*ast.StructType); !ok || !ts.Name.IsExported() || ts.TypeParams != nil {
+						continue
+					}
+					obj, ok := pkg.TypesInfo.Defs[ts.Name].(*types.TypeName)
+					if !ok {
+						continue
+					}
+					// 仅作为嵌入字段的结构体(如公共字段)不是表, 除非有 TableName 方法.
+					if _, ok := embeddeds[ts.Name.Name]; ok && tableNames[ts.Name.Name] == "" {
+						continue
+					}
+					doc := ts.Doc
+					if doc == nil && len(gd.Specs) == 1 {
+						doc = gd.Doc
+					}
+					m := &model{
+						Name:      ts.Name.Name,
+						TableName: cmp.Or(tableNames[ts.Name.Name], namer.TableName(ts.Name.Name)),
+						Comment:   strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(doc.Text()), ts.Name.Name)),
+						Fields:    structFields(obj.Type().Underlying().(*types.Struct), "", comments),
+					}
+					if len(m.Fields) == 0 {
+						continue
+					}
+					models = append(models, m)
+				}
+			}
+		}
+	}
+	return models, nil
+}
+
+// embeddedNames returns the struct names of the package which are embedded in the other structs.
+func embeddedNames(pkg *types.Package) map[string]struct{} {
+	mp := make(map[string]struct{})
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		for i := 0; i < st.NumFields(); i++ {
+			v := st.Field(i)
+			settings := gormschema.ParseTagSetting(reflect.StructTag(st.Tag(i)).Get("gorm"), ";")
+			if _, embedded := settings["EMBEDDED"]; !v.Embedded() && !embedded {
+				continue
+			}
+			t := v.Type()
+			if p, ok := t.(*types.Pointer); ok {
+				t = p.Elem()
+			}
+			if named, ok := t.(*types.Named); ok && named.Obj().Pkg() == pkg {
+				mp[named.Obj().Name()] = struct{}{}
+			}
+		}
+	}
+	return mp
+}
+
+// tableNameMethods find the `TableName() string` method which only return a string literal.
+func tableNameMethods(files []*ast.File) map[string]string {
+	mp := make(map[string]string)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || len(fd.Recv.List) != 1 || fd.Name.Name != "TableName" ||
+				fd.Body == nil || len(fd.Body.List) != 1 {
+				continue
+			}
+			recv := fd.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			ident, ok := recv.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			ret, ok := fd.Body.List[0].(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				continue
+			}
+			lit, ok := ret.Results[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				continue
+			}
+			if v, err := strconv.Unquote(lit.Value); err == nil {
+				mp[ident.Name] = v
+			}
+		}
+	}
+	return mp
+}
+
+// fieldComments the struct field comments, field name position -> comment.
+func fieldComments(files []*ast.File) map[token.Pos]string {
+	mp := make(map[token.Pos]string)
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			st, ok := n.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			for _, f := range st.Fields.List {
+				comment := f.Comment.Text()
+				if comment == "" {
+					comment = f.Doc.Text()
+				}
+				comment = strings.TrimSpace(comment)
+				for _, name := range f.Names {
+					mp[name.Pos()] = comment
+				}
+			}
+			return true
+		})
+	}
+	return mp
+}
+
+func structFields(st *types.Struct, prefix string, comments map[token.Pos]string) []*field {
+	fields := make([]*field, 0, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i)).Get("gorm")
+		if !v.Exported() || ignoreField(tag) {
+			continue
+		}
+		settings := gormschema.ParseTagSetting(tag, ";")
+		if _, embedded := settings["EMBEDDED"]; v.Embedded() || embedded {
+			if es, ok := embeddedStruct(v.Type()); ok {
+				fields = append(fields, structFields(es, prefix+settings["EMBEDDEDPREFIX"], comments)...)
+				continue
+			}
+		}
+		t, pointer, nullable, ok := intoGoType(v.Type(), settings)
+		if !ok { // maybe a relation, ignore it.
+			continue
+		}
+		fields = append(fields, &field{
+			Name:     v.Name(),
+			Prefix:   prefix,
+			Type:     t,
+			Pointer:  pointer,
+			Nullable: nullable,
+			Tag:      tag,
+			Comment:  comments[v.Pos()],
+		})
+	}
+	return fields
+}
+
+// ignoreField reports whether the field is not a column, `gorm:"-"`, `gorm:"-:all"`, `gorm:"-:migration"`.
+func ignoreField(tag string) bool {
+	v, ok := gormschema.ParseTagSetting(tag, ";")["-"]
+	return ok && (v == "-" || v == "all" || v == "migration")
+}
+
+// embeddedStruct returns the struct which should be expanded.
+func embeddedStruct(t types.Type) (*types.Struct, bool) {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if _, ok := wellKnownType(t); ok {
+		return nil, false
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	return st, ok
+}
+
+func qualifiedName(t types.Type) string {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	return named.Obj().Pkg().Path() + "." + named.Obj().Name()
+}
+
+// wellKnown the well known go type.
+type wellKnown struct {
+	Type     ens.Type
+	Nullable bool // can hold null
+}
+
+// wellKnownTypes qualified type name -> well known type.
+var wellKnownTypes = map[string]wellKnown{
+	"time.Time":                                 {ens.TypeTime, false},
+	"gorm.io/datatypes.Date":                    {ens.TypeDate, false},
+	"gorm.io/gorm.DeletedAt":                    {ens.TypeTime, true},
+	"database/sql.NullTime":                     {ens.TypeTime, true},
+	"database/sql.NullBool":                     {ens.TypeBool, true},
+	"database/sql.NullByte":                     {ens.TypeUint8, true},
+	"database/sql.NullInt16":                    {ens.TypeInt16, true},
+	"database/sql.NullInt32":                    {ens.TypeInt32, true},
+	"database/sql.NullInt64":                    {ens.TypeInt64, true},
+	"database/sql.NullFloat64":                  {ens.TypeFloat64, true},
+	"database/sql.NullString":                   {ens.TypeString, true},
+	"encoding/json.RawMessage":                  {ens.TypeJSON, false},
+	"gorm.io/datatypes.JSON":                    {ens.TypeJSON, false},
+	"gorm.io/datatypes.JSONMap":                 {ens.TypeJSON, false},
+	"gorm.io/datatypes.JSONType":                {ens.TypeJSON, false},
+	"gorm.io/datatypes.JSONSlice":               {ens.TypeJSON, false},
+	"github.com/shopspring/decimal.Decimal":     {ens.TypeDecimal, false},
+	"github.com/shopspring/decimal.NullDecimal": {ens.TypeDecimal, true},
+	"github.com/google/uuid.UUID":               {ens.TypeUUID, false},
+	"github.com/gofrs/uuid.UUID":                {ens.TypeUUID, false},
+	"gorm.io/datatypes.UUID":                    {ens.TypeUUID, false},
+}
+
+func wellKnownType(t types.Type) (wellKnown, bool) {
+	v, ok := wellKnownTypes[qualifiedName(t)]
+	return v, ok
+}
+
+// intoGoType convert the go type, return the go type without pointer, and whether it is a pointer,
+// or can hold null, ok false means it is not a column, maybe a relation.
+func intoGoType(t types.Type, settings map[string]string) (gt ens.GoType, pointer, nullable, ok bool) {
+	if p, isPtr := t.(*types.Pointer); isPtr {
+		pointer, t = true, p.Elem()
+	}
+	typ := ens.TypeInvalid
+	elemPkgPath := ""
+	if elem, isNull := nullTypeArgument(t); isNull { // sql.Null[T], datatypes.Null[T]
+		et, _, _, isColumn := intoGoType(elem, settings)
+		if !isColumn {
+			return gt, false, false, false
+		}
+		typ, nullable, elemPkgPath = et.Type, true, et.PkgPath
+	} else if v, isWellKnown := wellKnownType(t); isWellKnown {
+		typ, nullable = v.Type, v.Nullable
+	} else {
+		switch u := t.Underlying().(type) {
+		case *types.Basic:
+			typ = basicType(u, settings)
+		case *types.Slice:
+			if b, isBasic := u.Elem().Underlying().(*types.Basic); isBasic && b.Kind() == types.Byte {
+				typ = ens.TypeBytes
+			}
+		case *types.Array:
+			if b, isBasic := u.Elem().Underlying().(*types.Basic); isBasic && b.Kind() == types.Byte {
+				typ = ens.TypeBytes
+			}
+		}
+		if typ == ens.TypeInvalid {
+			switch {
+			case settings["SERIALIZER"] != "":
+				typ = ens.TypeJSON
+			case settings["TYPE"] != "" || isScannerValuer(t):
+				typ = ens.TypeOther
+			default:
+				return gt, false, false, false
+			}
+		}
+	}
+	ident := types.TypeString(t, func(p *types.Package) string { return p.Name() })
+	pkgPath := ""
+	if named, isNamed := t.(*types.Named); isNamed && named.Obj().Pkg() != nil {
+		pkgPath = named.Obj().Pkg().Path()
+	}
+	name, _, _ := strings.Cut(ident, "[") // generic type, like sql.Null[time.Time]
+	nonPointer := false
+	switch t.Underlying().(type) {
+	case *types.Slice, *types.Map, *types.Pointer:
+		nonPointer = true
+	}
+	return ens.GoType{
+		Type:         typ,
+		Ident:        ident,
+		PkgPath:      pkgPath,
+		PkgQualifier: utils.PkgQualifier(name),
+		NonPointer:   nonPointer,
+		ElemPkgPath:  elemPkgPath,
+	}, pointer, nullable, true
+}
+
+// nullTypeArgument returns the type argument of the generic nullable wrapper, like sql.Null[T], datatypes.Null[T].
+func nullTypeArgument(t types.Type) (types.Type, bool) {
+	named, ok := t.(*types.Named)
+	if !ok || named.TypeArgs().Len() != 1 {
+		return nil, false
+	}
+	if !isNullWrapper(qualifiedName(t)) {
+		return nil, false
+	}
+	return named.TypeArgs().At(0), true
+}
+
+// isNullWrapper reports whether the qualified type name is the generic nullable wrapper.
+func isNullWrapper(name string) bool {
+	return name == "database/sql.Null" || name == "gorm.io/datatypes.Null"
+}
+
+func basicType(b *types.Basic, settings map[string]string) ens.Type {
+	switch b.Kind() { // nolint: exhaustive
+	case types.Bool:
+		return ens.TypeBool
+	case types.Int:
+		return ens.TypeInt
+	case types.Int8:
+		return ens.TypeInt8
+	case types.Int16:
+		return ens.TypeInt16
+	case types.Int32:
+		return ens.TypeInt32
+	case types.Int64:
+		return ens.TypeInt64
+	case types.Uint:
+		return ens.TypeUint
+	case types.Uint8:
+		return ens.TypeUint8
+	case types.Uint16:
+		return ens.TypeUint16
+	case types.Uint32:
+		return ens.TypeUint32
+	case types.Uint64:
+		return ens.TypeUint64
+	case types.Float32:
+		return ens.TypeFloat32
+	case types.Float64:
+		return ens.TypeFloat64
+	case types.String:
+		if v := strings.ToUpper(settings["TYPE"]); strings.Contains(v, "DECIMAL") || strings.Contains(v, "NUMERIC") {
+			return ens.TypeDecimal
+		}
+		return ens.TypeString
+	default:
+		return ens.TypeInvalid
+	}
+}
+
+// isScannerValuer reports whether the type implement sql.Scanner and driver.Valuer.
+func isScannerValuer(t types.Type) bool {
+	ms := types.NewMethodSet(types.NewPointer(t))
+	return ms.Lookup(nil, "Scan") != nil && ms.Lookup(nil, "Value") != nil
+}
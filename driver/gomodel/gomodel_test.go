@@ -0,0 +1,166 @@
+package gomodel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+	"github.com/things-go/ens/driver/mysql"
+)
+
+func Test_GoModel(t *testing.T) {
+	d := &GoModel{}
+	sc, err := d.InspectSchema(context.Background(), &driver.InspectOption{URL: driver.GoModel + "://./testdata/model"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sc.Entities) != 2 { // the embedded Base is not a table
+		t.Fatalf("entities count = %d, want 2", len(sc.Entities))
+	}
+	user, post := sc.Entities[0], sc.Entities[1]
+	if user.Name != "user" || user.Comment != "用户表" || post.Name != "posts" {
+		t.Fatalf("unexpected tables: %s(%s), %s", user.Name, user.Comment, post.Name)
+	}
+
+	wantUser := []struct {
+		column   string
+		goType   string
+		nullable bool
+		tag      string
+	}{
+		{"id", "int64", false, `gorm:"column:id;not null;autoIncrement:true;primaryKey"`},
+		{"name", "string", false, `gorm:"column:name;type:varchar(64);not null;default:'';index:idx_name_age,priority:0;comment:名称"`},
+		{"age", "int", false, `gorm:"column:age;type:bigint;not null;index:idx_name_age,priority:1"`},
+		{"email", "string", true, `gorm:"column:email;type:varchar(128);default:null;uniqueIndex:idx_user_email"`},
+		{"status", "model.Status", false, `gorm:"column:status;type:tinyint;not null;default:1"`},
+		{"balance", "string", false, `gorm:"column:balance;type:decimal(10,2);not null"`},
+		{"nickname", "sql.NullString", true, `gorm:"column:nickname;type:varchar(32);default:null"`},
+		{"profile", "datatypes.JSON", true, `gorm:"column:profile;type:json;default:null"`},
+		{"created_at", "time.Time", true, `gorm:"column:created_at;type:datetime(3);default:null"`},
+		{"deleted_at", "gorm.DeletedAt", true, `gorm:"column:deleted_at;type:datetime(3);default:null;index:idx_user_deleted_at"`},
+		{"score", "sql.Null[int32]", true, `gorm:"column:score;type:int;default:null"`},
+		{"birthday", "datatypes.Null[time.Time]", true, `gorm:"column:birthday;type:datetime(3);default:null"`},
+	}
+	if len(user.Fields) != len(wantUser) {
+		t.Fatalf("fields count = %d, want %d", len(user.Fields), len(wantUser))
+	}
+	for i, want := range wantUser {
+		got := user.Fields[i]
+		if got.ColumnName != want.column || got.Type.Ident != want.goType || got.Nullable != want.nullable || got.Tags[0] != want.tag {
+			t.Errorf("field %d = %s(%s, %v) %s, want %s(%s, %v) %s", i,
+				got.ColumnName, got.Type.Ident, got.Nullable, got.Tags[0], want.column, want.goType, want.nullable, want.tag)
+		}
+	}
+	if user.Fields[3].Type.Type != ens.TypeString || !user.Fields[3].GoPointer {
+		t.Errorf("email should be a string pointer")
+	}
+	if got := user.Fields[11].Type; got.Type != ens.TypeTime || got.PkgQualifier != "datatypes" || got.ElemPkgPath != "time" {
+		t.Errorf("unexpected birthday type %+v", got)
+	}
+	if user.Fields[4].Comment != "状态" || user.Fields[5].Type.Type != ens.TypeDecimal {
+		t.Errorf("unexpected status comment(%s) or balance type(%v)", user.Fields[4].Comment, user.Fields[5].Type.Type)
+	}
+
+	columns := make([]string, 0, len(post.Fields))
+	for _, field := range post.Fields {
+		columns = append(columns, field.ColumnName)
+	}
+	if got, want := strings.Join(columns, ","), "id,created_at,updated_at,deleted_at,audit_created_by,user_id,title,content"; got != want {
+		t.Errorf("post columns = %v, want %v", got, want)
+	}
+	definition := post.Table.Definition()
+	for _, want := range []string{
+		"`id` bigint unsigned NOT NULL,",
+		"`content` varbinary(1024) DEFAULT NULL,",
+		"PRIMARY KEY (`id`,`user_id`) USING BTREE",
+		"UNIQUE KEY `uni_posts_title` (`title`) USING BTREE",
+		"KEY `idx_posts_deleted_at` (`deleted_at`) USING BTREE",
+	} {
+		if !strings.Contains(definition, want) {
+			t.Errorf("definition missing %q, got:\n%s", want, definition)
+		}
+	}
+}
+
+func Test_GoModel_Tables(t *testing.T) {
+	d := &GoModel{}
+	arg := &driver.InspectOption{URL: driver.GoModel + "://./testdata/model"}
+	arg.Tables = []string{"user", "posts"}
+	arg.Exclude = []string{"p*"}
+	sc, err := d.InspectSchema(context.Background(), arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sc.Entities) != 1 || sc.Entities[0].Name != "user" {
+		t.Fatalf("unexpected entities: %v", sc.Entities)
+	}
+}
+
+type article struct {
+	ID      uint64  `gorm:"primaryKey"`
+	Title   string  `gorm:"size:128;not null;default:'';uniqueIndex:uk_title;comment:标题"`
+	Content string  `gorm:"type:text;index:idx_content,class:FULLTEXT"`
+	Author  *string `gorm:"size:32;index:idx_author_score,priority:1"`
+	Score   float64 `gorm:"not null;index:idx_author_score,priority:2"`
+}
+
+func Test_ParseModel(t *testing.T) {
+	entity, err := ens.ParseModel(&article{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entity.Name != "articles" {
+		t.Fatalf("table name = %s, want articles", entity.Name)
+	}
+	definition := entity.IntoSQL().Sql
+	for _, want := range []string{
+		"`id` bigint unsigned NOT NULL AUTO_INCREMENT,",
+		"`title` varchar(128) NOT NULL DEFAULT '' COMMENT '标题',",
+		"`content` text DEFAULT NULL,",
+		"`author` varchar(32) DEFAULT NULL,",
+		"`score` double NOT NULL,",
+		"PRIMARY KEY (`id`) USING BTREE",
+		"UNIQUE KEY `uk_title` (`title`) USING BTREE",
+		"FULLTEXT KEY `idx_content` (`content`)",
+		"KEY `idx_author_score` (`author`,`score`) USING BTREE",
+	} {
+		if !strings.Contains(definition, want) {
+			t.Errorf("definition missing %q, got:\n%s", want, definition)
+		}
+	}
+	if _, err = ens.ParseModel(1); err == nil {
+		t.Errorf("should be failed with non struct")
+	}
+}
+
+func Test_GoModel_Diff(t *testing.T) {
+	to, err := (&GoModel{}).InspectSchema(context.Background(), &driver.InspectOption{URL: driver.GoModel + "://./testdata/model"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	migration, err := ens.Diff(nil, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	err = os.WriteFile(filepath.Join(dir, "1_init.up.sql"), []byte(strings.Join(migration.Up, ";\n")+";\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the schema rebuilt from the migration is synced with the model.
+	from, err := (&mysql.Migrate{}).InspectSchema(context.Background(), &driver.InspectOption{URL: driver.MigrateMysql + "://" + dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	migration, err = ens.Diff(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migration.Up) != 0 || len(migration.Down) != 0 {
+		t.Errorf("should be no changes, got: %q, %q", migration.Up, migration.Down)
+	}
+}
@@ -0,0 +1,371 @@
+package gomodel
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/schema"
+	gormschema "gorm.io/gorm/schema"
+
+	"github.com/things-go/ens"
+	ensmysql "github.com/things-go/ens/driver/mysql"
+	"github.com/things-go/ens/internal/insql"
+)
+
+// gorm general data type, `type:string` means use the default column type.
+var gormDataTypes = []string{"bool", "int", "uint", "float", "string", "time", "bytes"}
+
+// column a column parsed from field.
+type column struct {
+	field    *field
+	col      *schema.Column
+	settings map[string]string
+}
+
+// index an index parsed from gorm tag.
+type index struct {
+	name     string
+	unique   bool
+	typ      string
+	parts    []*indexPart
+	priority int
+}
+
+type indexPart struct {
+	col      *schema.Column
+	priority int
+}
+
+func intoSchema(m *model) (*ens.EntityDescriptor, error) {
+	table, err := intoTable(m)
+	if err != nil {
+		return nil, err
+	}
+	// * columns
+	fielders := make([]*ens.FieldDescriptor, 0, len(m.Fields))
+	for i, f := range m.Fields {
+		col := table.Columns[i]
+		fielders = append(fielders, &ens.FieldDescriptor{
+			ColumnName: col.Name,
+			Comment:    cmp.Or(insql.MustComment(col.Attrs), f.Comment),
+			Nullable:   col.Type.Null,
+			Column:     ensmysql.NewColumnDef(col),
+			Type:       f.Type,
+			GoName:     f.Name,
+			GoPointer:  f.Pointer,
+			Tags:       []string{ensmysql.NewColumnDef(col).GormTag(table)},
+		})
+	}
+	// * indexes
+	indexers := make([]*ens.IndexDescriptor, 0, len(table.Indexes))
+	for _, index := range table.Indexes {
+		indexers = append(indexers, &ens.IndexDescriptor{
+			Name:   index.Name,
+			Fields: insql.IndexPartColumnNames(index.Parts),
+			Index:  ensmysql.NewIndexDef(index),
+		})
+	}
+	// * table
+	return &ens.EntityDescriptor{
+		Name:        table.Name,
+		Comment:     m.Comment,
+		Table:       ensmysql.NewTableDef(table),
+		Fields:      fielders,
+		Indexes:     indexers,
+		ForeignKeys: []*ens.ForeignKeyDescriptor{},
+	}, nil
+}
+
+// intoTable convert the model to mysql table, the columns are in the same order with fields.
+func intoTable(m *model) (*schema.Table, error) {
+	table := schema.NewTable(m.TableName)
+	if m.Comment != "" {
+		table.SetComment(m.Comment)
+	}
+	columns := make([]*column, 0, len(m.Fields))
+	for _, f := range m.Fields {
+		settings := gormschema.ParseTagSetting(f.Tag, ";")
+		name := f.Prefix + cmp.Or(settings["COLUMN"], namer.ColumnName("", f.Name))
+		if _, ok := table.Column(name); ok {
+			return nil, fmt.Errorf("duplicate column '%s' in model '%s'", name, m.Name)
+		}
+		col := schema.NewColumn(name)
+		if comment := settings["COMMENT"]; comment != "" {
+			col.SetComment(comment)
+		}
+		table.AddColumns(col)
+		columns = append(columns, &column{field: f, col: col, settings: settings})
+	}
+
+	//* primary key, gorm use the `id` field as primary key if not specified.
+	pkColumns := make([]*column, 0, 2)
+	for _, c := range columns {
+		if hasSetting(c.settings, "PRIMARYKEY") || hasSetting(c.settings, "PRIMARY_KEY") {
+			pkColumns = append(pkColumns, c)
+		}
+	}
+	if len(pkColumns) == 0 {
+		if i := slices.IndexFunc(columns, func(c *column) bool { return c.col.Name == "id" }); i != -1 {
+			pkColumns = append(pkColumns, columns[i])
+		}
+	}
+
+	//* column type
+	for _, c := range columns {
+		isPk := slices.Contains(pkColumns, c)
+		raw := columnType(c.field, c.settings, isPk)
+		typ, err := mysql.ParseType(raw)
+		if err != nil {
+			typ = &schema.UnsupportedType{T: raw}
+		}
+		c.col.Type = &schema.ColumnType{
+			Type: typ,
+			Raw:  raw,
+			Null: !isPk && !hasSetting(c.settings, "NOT NULL") && !hasSetting(c.settings, "NOTNULL"),
+		}
+		// gorm set auto increment for the single integer primary key by default.
+		if v, ok := c.settings["AUTOINCREMENT"]; ok && !strings.EqualFold(v, "false") ||
+			!ok && isPk && len(pkColumns) == 1 && c.field.Type.Type.IsInteger() {
+			c.col.AddAttrs(&mysql.AutoIncrement{})
+		}
+		if expr := defaultValue(c.settings, c.field); expr != nil {
+			c.col.SetDefault(expr)
+		}
+	}
+	if len(pkColumns) > 0 {
+		pk := schema.NewPrimaryKey()
+		pk.Name = "PRIMARY"
+		for _, c := range pkColumns {
+			pk.AddColumns(c.col)
+		}
+		table.SetPrimaryKey(pk)
+		table.AddIndexes(pk)
+	}
+
+	//* indexes
+	indexes := make([]*index, 0, 8)
+	addIndex := func(idx *index, col *schema.Column) error {
+		i := slices.IndexFunc(indexes, func(v *index) bool { return v.name == idx.name })
+		if i == -1 {
+			indexes = append(indexes, idx)
+		} else {
+			if indexes[i].unique != idx.unique {
+				return fmt.Errorf("index '%s' in model '%s' has different unique", idx.name, m.Name)
+			}
+			indexes[i].typ = cmp.Or(indexes[i].typ, idx.typ)
+			idx = indexes[i]
+		}
+		idx.parts = append(idx.parts, &indexPart{col: col, priority: idx.priority})
+		return nil
+	}
+	for _, c := range columns {
+		for _, v := range parseIndexes(table.Name, c) {
+			if err := addIndex(v, c.col); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, v := range indexes {
+		slices.SortStableFunc(v.parts, func(a, b *indexPart) int { return cmp.Compare(a.priority, b.priority) })
+		idx := schema.NewIndex(v.name).
+			SetUnique(v.unique).
+			AddAttrs(&mysql.IndexType{T: cmp.Or(strings.ToUpper(v.typ), mysql.IndexTypeBTree)})
+		for _, p := range v.parts {
+			idx.AddColumns(p.col)
+		}
+		table.AddIndexes(idx)
+	}
+	return table, nil
+}
+
+// parseIndexes parse the index from gorm tag `index`, `uniqueIndex` and `unique`, a field may be in multiple indexes.
+//
+//	index:[name][,unique][,class:FULLTEXT][,type:btree][,priority:10]
+//	uniqueIndex:[name][,type:btree][,priority:10]
+func parseIndexes(tableName string, c *column) []*index {
+	indexes := make([]*index, 0, 2)
+	for _, entry := range splitTag(c.field.Tag, ';') {
+		key, value, _ := strings.Cut(entry, ":")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		switch key {
+		case "INDEX", "UNIQUEINDEX":
+			name, opts, _ := strings.Cut(value, ",")
+			if strings.Contains(name, ":") {
+				name, opts = "", value
+			}
+			settings := gormschema.ParseTagSetting(opts, ",")
+			class := strings.ToUpper(settings["CLASS"])
+			idx := &index{
+				name:     strings.TrimSpace(name),
+				unique:   key == "UNIQUEINDEX" || hasSetting(settings, "UNIQUE") || class == "UNIQUE",
+				typ:      cmp.Or(settings["TYPE"], settings["USING"]),
+				priority: 10,
+			}
+			if class == "FULLTEXT" || class == "SPATIAL" {
+				idx.typ = class
+			}
+			if v, err := strconv.Atoi(settings["PRIORITY"]); err == nil {
+				idx.priority = v
+			}
+			if idx.name == "" {
+				idx.name = namer.IndexName(tableName, c.col.Name)
+			}
+			indexes = append(indexes, idx)
+		case "UNIQUE":
+			indexes = append(indexes, &index{
+				name:     namer.UniqueName(tableName, c.col.Name),
+				unique:   true,
+				priority: 10,
+			})
+		}
+	}
+	return indexes
+}
+
+// columnType returns the mysql column type, same as gorm mysql dialector if not specified `type`.
+func columnType(f *field, settings map[string]string, isPk bool) string {
+	if t := settings["TYPE"]; t != "" && !slices.Contains(gormDataTypes, strings.ToLower(t)) {
+		return t
+	}
+	size, _ := strconv.Atoi(settings["SIZE"])
+	precision, _ := strconv.Atoi(settings["PRECISION"])
+	scale, _ := strconv.Atoi(settings["SCALE"])
+
+	switch t := f.Type.Type; t { // nolint: exhaustive
+	case ens.TypeBool:
+		return "tinyint(1)"
+	case ens.TypeInt8, ens.TypeInt16, ens.TypeInt32, ens.TypeInt64, ens.TypeInt,
+		ens.TypeUint8, ens.TypeUint16, ens.TypeUint32, ens.TypeUint64, ens.TypeUint:
+		if size == 0 {
+			switch t { // nolint: exhaustive
+			case ens.TypeInt8, ens.TypeUint8:
+				size = 8
+			case ens.TypeInt16, ens.TypeUint16:
+				size = 16
+			case ens.TypeInt32, ens.TypeUint32:
+				size = 32
+			default:
+				size = 64
+			}
+		}
+		raw := "bigint"
+		switch {
+		case size <= 8:
+			raw = "tinyint"
+		case size <= 16:
+			raw = "smallint"
+		case size <= 24:
+			raw = "mediumint"
+		case size <= 32:
+			raw = "int"
+		}
+		if t >= ens.TypeUint8 && t <= ens.TypeUint {
+			raw += " unsigned"
+		}
+		return raw
+	case ens.TypeFloat32, ens.TypeFloat64:
+		if precision > 0 {
+			return fmt.Sprintf("decimal(%d,%d)", precision, scale)
+		}
+		if t == ens.TypeFloat32 || size > 0 && size <= 32 {
+			return "float"
+		}
+		return "double"
+	case ens.TypeDecimal:
+		if precision > 0 {
+			return fmt.Sprintf("decimal(%d,%d)", precision, scale)
+		}
+		return "decimal(10,0)"
+	case ens.TypeString, ens.TypeEnum:
+		if size == 0 {
+			_, hasDefault := settings["DEFAULT"]
+			hasIndex := settings["INDEX"] != "" || settings["UNIQUE"] != "" || settings["UNIQUEINDEX"] != ""
+			if isPk || hasDefault || hasIndex {
+				size = 191
+			}
+		}
+		switch {
+		case size >= 65536 && size <= 1<<24:
+			return "mediumtext"
+		case size > 0 && size < 65536:
+			return fmt.Sprintf("varchar(%d)", size)
+		default:
+			return "longtext"
+		}
+	case ens.TypeTime:
+		if precision == 0 {
+			precision = 3
+		}
+		return fmt.Sprintf("datetime(%d)", precision)
+	case ens.TypeDate:
+		return "date"
+	case ens.TypeBytes:
+		switch {
+		case size > 0 && size < 65536:
+			return fmt.Sprintf("varbinary(%d)", size)
+		case size >= 65536 && size <= 1<<24:
+			return "mediumblob"
+		default:
+			return "longblob"
+		}
+	case ens.TypeJSON:
+		return "json"
+	case ens.TypeUUID:
+		return "char(36)"
+	default:
+		return "longtext"
+	}
+}
+
+// defaultValue returns the column default value.
+// `default:(-)` means has default value in database, but not in migration, `default:null` means no default.
+func defaultValue(settings map[string]string, f *field) schema.Expr {
+	v, ok := settings["DEFAULT"]
+	if !ok || v == "(-)" || strings.EqualFold(v, "null") {
+		return nil
+	}
+	upper := strings.ToUpper(v)
+	if strings.Contains(v, "(") || upper == "CURRENT_TIMESTAMP" || upper == "NOW()" {
+		return &schema.RawExpr{X: v}
+	}
+	if f.Type.Type == ens.TypeBool {
+		switch strings.ToLower(v) {
+		case "true":
+			v = "1"
+		case "false":
+			v = "0"
+		}
+	}
+	return &schema.Literal{V: strings.Trim(v, `'"`)}
+}
+
+func hasSetting(settings map[string]string, key string) bool {
+	_, ok := settings[key]
+	return ok
+}
+
+// splitTag split the tag by sep, same as gorm, `\` escape the sep.
+func splitTag(tag string, sep byte) []string {
+	entries := make([]string, 0, 8)
+	b := &strings.Builder{}
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == sep:
+			b.WriteByte(sep)
+			i++
+		case tag[i] == sep:
+			entries = append(entries, b.String())
+			b.Reset()
+		default:
+			b.WriteByte(tag[i])
+		}
+	}
+	if b.Len() > 0 {
+		entries = append(entries, b.String())
+	}
+	return entries
+}
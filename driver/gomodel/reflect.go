@@ -0,0 +1,138 @@
+package gomodel
+
+import (
+	"go/types"
+	"reflect"
+	"strings"
+
+	gormschema "gorm.io/gorm/schema"
+
+	"github.com/things-go/ens"
+)
+
+var (
+	scannerMethods  = []string{"Scan", "Value"}
+	reflectKindType = map[reflect.Kind]ens.Type{
+		reflect.Bool:    ens.TypeBool,
+		reflect.Int:     ens.TypeInt,
+		reflect.Int8:    ens.TypeInt8,
+		reflect.Int16:   ens.TypeInt16,
+		reflect.Int32:   ens.TypeInt32,
+		reflect.Int64:   ens.TypeInt64,
+		reflect.Uint:    ens.TypeUint,
+		reflect.Uint8:   ens.TypeUint8,
+		reflect.Uint16:  ens.TypeUint16,
+		reflect.Uint32:  ens.TypeUint32,
+		reflect.Uint64:  ens.TypeUint64,
+		reflect.Float32: ens.TypeFloat32,
+		reflect.Float64: ens.TypeFloat64,
+		reflect.String:  ens.TypeString,
+	}
+)
+
+// parseModel parse the go model struct with reflection, same as the GoModel driver but without loading the package,
+// so the comments only from gorm tag `comment`, registered as the ens.ParseModel parser.
+func parseModel(vt reflect.Type) (*ens.EntityDescriptor, error) {
+	tableName := namer.TableName(vt.Name())
+	if tabler, ok := reflect.New(vt).Interface().(gormschema.Tabler); ok {
+		tableName = tabler.TableName()
+	}
+	return intoSchema(&model{
+		Name:      vt.Name(),
+		TableName: tableName,
+		Comment:   "",
+		Fields:    reflectFields(vt, ""),
+	})
+}
+
+func reflectFields(vt reflect.Type, prefix string) []*field {
+	fields := make([]*field, 0, vt.NumField())
+	for i := 0; i < vt.NumField(); i++ {
+		fv := vt.Field(i)
+		tag := fv.Tag.Get("gorm")
+		if !fv.IsExported() || ignoreField(tag) {
+			continue
+		}
+		settings := gormschema.ParseTagSetting(tag, ";")
+		if _, embedded := settings["EMBEDDED"]; fv.Anonymous || embedded {
+			ft := fv.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if _, ok := wellKnownTypes[ft.PkgPath()+"."+ft.Name()]; !ok && ft.Kind() == reflect.Struct {
+				fields = append(fields, reflectFields(ft, prefix+settings["EMBEDDEDPREFIX"])...)
+				continue
+			}
+		}
+		t, pointer, nullable, ok := reflectGoType(fv.Type, settings)
+		if !ok { // maybe a relation, ignore it.
+			continue
+		}
+		fields = append(fields, &field{
+			Name:     fv.Name,
+			Prefix:   prefix,
+			Type:     t,
+			Pointer:  pointer,
+			Nullable: nullable,
+			Tag:      tag,
+			Comment:  "",
+		})
+	}
+	return fields
+}
+
+// reflectGoType same as intoGoType, but with reflection.
+func reflectGoType(rt reflect.Type, settings map[string]string) (gt ens.GoType, pointer, nullable, ok bool) {
+	if rt.Kind() == reflect.Pointer {
+		pointer, rt = true, rt.Elem()
+	}
+	if rt.Kind() == reflect.Interface {
+		return gt, false, false, false
+	}
+	// generic type name contains the type arguments, like `Null[int64]`
+	name, _, _ := strings.Cut(rt.Name(), "[")
+	typ := ens.TypeInvalid
+	if rt.Kind() == reflect.Struct && isNullWrapper(rt.PkgPath()+"."+name) { // sql.Null[T], datatypes.Null[T]
+		f, _ := rt.FieldByName("V")
+		et, _, _, isColumn := reflectGoType(f.Type, settings)
+		if !isColumn {
+			return gt, false, false, false
+		}
+		typ, nullable = et.Type, true
+	} else if v, isWellKnown := wellKnownTypes[rt.PkgPath()+"."+name]; isWellKnown {
+		typ, nullable = v.Type, v.Nullable
+	} else {
+		switch rt.Kind() { // nolint: exhaustive
+		case reflect.Slice, reflect.Array:
+			if rt.Elem().Kind() == reflect.Uint8 {
+				typ = ens.TypeBytes
+			}
+		case reflect.String:
+			typ = basicType(types.Typ[types.String], settings)
+		default:
+			typ = reflectKindType[rt.Kind()]
+		}
+		if typ == ens.TypeInvalid {
+			switch {
+			case settings["SERIALIZER"] != "":
+				typ = ens.TypeJSON
+			case settings["TYPE"] != "" || isReflectScannerValuer(rt):
+				typ = ens.TypeOther
+			default:
+				return gt, false, false, false
+			}
+		}
+	}
+	return ens.NewGoType(typ, reflect.Zero(rt).Interface()), pointer, nullable, true
+}
+
+// isReflectScannerValuer reports whether the type implement sql.Scanner and driver.Valuer.
+func isReflectScannerValuer(rt reflect.Type) bool {
+	pt := reflect.PointerTo(rt)
+	for _, name := range scannerMethods {
+		if _, ok := pt.MethodByName(name); !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type Status int8
+
+// User 用户表
+type User struct {
+	ID        int64          `gorm:"column:id;autoIncrement:true;primaryKey"`
+	Name      string         `gorm:"column:name;type:varchar(64);not null;default:'';index:idx_name_age,priority:1;comment:名称"`
+	Age       int            `gorm:"not null;index:idx_name_age,priority:2"`
+	Email     *string        `gorm:"size:128;uniqueIndex"`
+	Status    Status         `gorm:"not null;default:1"` // 状态
+	Balance   string         `gorm:"type:decimal(10,2);not null"`
+	Nickname  sql.NullString `gorm:"size:32"`
+	Profile   datatypes.JSON
+	Posts     []Post `gorm:"foreignKey:UserID"`
+	Ignored   string `gorm:"-"`
+	CreatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	Score     sql.Null[int32]
+	Birthday  datatypes.Null[time.Time]
+}
+
+// TableName implement schema.Tabler interface
+func (*User) TableName() string {
+	return "user"
+}
+
+type Base struct {
+	CreatedBy string `gorm:"size:32"`
+}
+
+type Post struct {
+	gorm.Model
+	Base    `gorm:"embedded;embeddedPrefix:audit_"`
+	UserID  uint64 `gorm:"primaryKey"`
+	Title   string `gorm:"unique"`
+	Content []byte `gorm:"size:1024"`
+	User    *User
+}
+
+type request struct {
+	Name string
+}
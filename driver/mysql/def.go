@@ -1,70 +1,102 @@
 package mysql
 
 import (
-	"fmt"
-	"regexp"
-
 	"ariga.io/atlas/sql/schema"
 	"github.com/things-go/ens"
+	"github.com/things-go/ens/internal/insql"
 )
 
 // \b([(]\d+[)])? 匹配0个或1个(\d+)
+// 注释中的 enableXxx 为 ens.TypeOption 可切换的映射.
 var typeDictMatchList = []struct {
 	Key     string
 	NewType func() ens.GoType
 }{
-	{`^(bool)`, ens.BoolType},                                 // bool
-	{`^(tinyint)\b[(]1[)] unsigned`, ens.BoolType},            // bool
-	{`^(tinyint)\b[(]1[)]`, ens.BoolType},                     // bool
-	{`^(tinyint)\b([(]\d+[)])? unsigned`, ens.Uint8Type},      // uint8
-	{`^(tinyint)\b([(]\d+[)])?`, ens.Int8Type},                // int8
-	{`^(smallint)\b([(]\d+[)])? unsigned`, ens.Uint16Type},    // uint16
-	{`^(smallint)\b([(]\d+[)])?`, ens.Int16Type},              // int16
-	{`^(mediumint)\b([(]\d+[)])? unsigned`, ens.Uint32Type},   // uint32
-	{`^(mediumint)\b([(]\d+[)])?`, ens.Int32Type},             // int32
-	{`^(int)\b([(]\d+[)])? unsigned`, ens.Uint32Type},         // uint32
-	{`^(int)\b([(]\d+[)])?`, ens.Int32Type},                   // int32
-	{`^(integer)\b([(]\d+[)])? unsigned`, ens.Uint32Type},     // uint32
-	{`^(integer)\b([(]\d+[)])?`, ens.Int32Type},               // int32
-	{`^(bigint)\b([(]\d+[)])? unsigned`, ens.Uint64Type},      // uint64
-	{`^(bigint)\b([(]\d+[)])?`, ens.Int64Type},                // int64
-	{`^(float)\b([(]\d+,\d+[)])? unsigned`, ens.Float32Type},  // float32
-	{`^(float)\b([(]\d+,\d+[)])?`, ens.Float32Type},           // float32
-	{`^(double)\b([(]\d+,\d+[)])? unsigned`, ens.Float64Type}, // float64
-	{`^(double)\b([(]\d+,\d+[)])?`, ens.Float64Type},          // float64
-	{`^(char)\b[(]\d+[)]`, ens.StringType},                    // string
-	{`^(varchar)\b[(]\d+[)]`, ens.StringType},                 // string
-	{`^(datetime)\b([(]\d+[)])?`, ens.TimeType},               // time.Time
-	{`^(date)\b([(]\d+[)])?`, ens.TimeType},                   // datatypes.Date
-	{`^(timestamp)\b([(]\d+[)])?`, ens.TimeType},              // time.Time
-	{`^(time)\b([(]\d+[)])?`, ens.TimeType},                   // time.Time
-	{`^(year)\b([(]\d+[)])?`, ens.TimeType},                   // time.Time
-	{`^(text)\b([(]\d+[)])?`, ens.StringType},                 // string
-	{`^(tinytext)\b([(]\d+[)])?`, ens.StringType},             // string
-	{`^(mediumtext)\b([(]\d+[)])?`, ens.StringType},           // string
-	{`^(longtext)\b([(]\d+[)])?`, ens.StringType},             // string
-	{`^(blob)\b([(]\d+[)])?`, ens.BytesType},                  // []byte
-	{`^(tinyblob)\b([(]\d+[)])?`, ens.BytesType},              // []byte
-	{`^(mediumblob)\b([(]\d+[)])?`, ens.BytesType},            // []byte
-	{`^(longblob)\b([(]\d+[)])?`, ens.BytesType},              // []byte
-	{`^(bit)\b[(]\d+[)]`, ens.BytesType},                      // []uint8
-	{`^(json)\b`, ens.JSONRawMessageType},                     // datatypes.JSON
-	{`^(enum)\b[(](.)+[)]`, ens.StringType},                   // string
-	{`^(set)\b[(](.)+[)]`, ens.StringType},                    // string
-	{`^(decimal)\b[(]\d+,\d+[)]`, ens.DecimalType},            // string
-	{`^(binary)\b[(]\d+[)]`, ens.BytesType},                   // []byte
-	{`^(varbinary)\b[(]\d+[)]`, ens.BytesType},                // []byte
-	{`^(geometry)`, ens.StringType},                           // string
+	{`^(bool)`, ens.BoolType},                                        // bool
+	{`^(tinyint)\b[(]1[)] unsigned`, ens.BoolType},                   // bool
+	{`^(tinyint)\b[(]1[)]`, ens.BoolType},                            // bool
+	{`^(tinyint)\b([(]\d+[)])? unsigned`, ens.Uint8Type},             // uint8
+	{`^(tinyint)\b([(]\d+[)])?`, ens.Int8Type},                       // int8
+	{`^(smallint)\b([(]\d+[)])? unsigned`, ens.Uint16Type},           // uint16
+	{`^(smallint)\b([(]\d+[)])?`, ens.Int16Type},                     // int16
+	{`^(mediumint)\b([(]\d+[)])? unsigned`, ens.Uint32Type},          // uint32
+	{`^(mediumint)\b([(]\d+[)])?`, ens.Int32Type},                    // int32
+	{`^(int)\b([(]\d+[)])? unsigned`, ens.Uint32Type},                // uint32
+	{`^(int)\b([(]\d+[)])?`, ens.Int32Type},                          // int32
+	{`^(integer)\b([(]\d+[)])? unsigned`, ens.Uint32Type},            // uint32
+	{`^(integer)\b([(]\d+[)])?`, ens.Int32Type},                      // int32
+	{`^(bigint)\b([(]\d+[)])? unsigned`, ens.Uint64Type},             // uint64
+	{`^(bigint)\b([(]\d+[)])?`, ens.Int64Type},                       // int64
+	{`^(float)\b([(]\d+,\d+[)])? unsigned`, ens.Float32Type},         // float32
+	{`^(float)\b([(]\d+,\d+[)])?`, ens.Float32Type},                  // float32
+	{`^(double)\b([(]\d+,\d+[)])? unsigned`, ens.Float64Type},        // float64
+	{`^(double)\b([(]\d+,\d+[)])?`, ens.Float64Type},                 // float64
+	{`^(char)\b[(]36[)]`, ens.StringType},                            // string, enableUUID: datatypes.UUID
+	{`^(char)\b[(]\d+[)]`, ens.StringType},                           // string
+	{`^(varchar)\b[(]\d+[)]`, ens.StringType},                        // string
+	{`^(datetime)\b([(]\d+[)])?`, ens.TimeType},                      // time.Time
+	{`^(date)\b([(]\d+[)])?`, ens.TimeType},                          // time.Time, enableDatatypesDate: datatypes.Date
+	{`^(timestamp)\b([(]\d+[)])?`, ens.TimeType},                     // time.Time
+	{`^(time)\b([(]\d+[)])?`, ens.TimeType},                          // time.Time, enableDatatypesTime: datatypes.Time
+	{`^(year)\b([(]\d+[)])?`, ens.TimeType},                          // time.Time, enableYearInt: int16
+	{`^(text)\b([(]\d+[)])?`, ens.StringType},                        // string
+	{`^(tinytext)\b([(]\d+[)])?`, ens.StringType},                    // string
+	{`^(mediumtext)\b([(]\d+[)])?`, ens.StringType},                  // string
+	{`^(longtext)\b([(]\d+[)])?`, ens.StringType},                    // string
+	{`^(blob)\b([(]\d+[)])?`, ens.BytesType},                         // []byte
+	{`^(tinyblob)\b([(]\d+[)])?`, ens.BytesType},                     // []byte
+	{`^(mediumblob)\b([(]\d+[)])?`, ens.BytesType},                   // []byte
+	{`^(longblob)\b([(]\d+[)])?`, ens.BytesType},                     // []byte
+	{`^(bit)\b[(]1[)]`, ens.BytesType},                               // []byte, enableBitBool: bool
+	{`^(bit)\b[(]\d+[)]`, ens.BytesType},                             // []byte
+	{`^(json)\b`, ens.JSONRawMessageType},                            // datatypes.JSON
+	{`^(enum)\b[(](.)+[)]`, ens.EnumType},                            // string
+	{`^(set)\b[(](.)+[)]`, ens.StringType},                           // string
+	{`^(decimal)\b[(]\d+,\d+[)]`, ens.DecimalType},                   // string, enableDecimal: decimal.Decimal
+	{`^(binary)\b[(]16[)]`, ens.BytesType},                           // []byte, enableUUID: datatypes.BinUUID
+	{`^(binary)\b[(]\d+[)]`, ens.BytesType},                          // []byte
+	{`^(varbinary)\b[(]\d+[)]`, ens.BytesType},                       // []byte
+	{`^(geometry)`, ens.StringType},                                  // string
+	{`^(point|linestring|polygon)\b`, ens.StringType},                // string
+	{`^(multipoint|multilinestring|multipolygon)\b`, ens.StringType}, // string
+	{`^(geometrycollection|geomcollection)\b`, ens.StringType},       // string
+	{`^(vector)\b`, ens.BytesType},                                   // []byte
 }
 
-func intoGoType(columnType string) ens.GoType {
+// typeMapper the builtin type mappings, compiled once.
+var typeMapper = func() *ens.TypeMapper {
+	mappings := make([]ens.TypeMapping, 0, len(typeDictMatchList))
 	for _, v := range typeDictMatchList {
-		ok, _ := regexp.MatchString(v.Key, columnType)
-		if ok {
-			return v.NewType()
-		}
+		mappings = append(mappings, ens.TypeMapping{Pattern: v.Key, Type: v.NewType()})
+	}
+	return ens.MustTypeMapper(mappings...)
+}()
+
+// otherType the go type of the unknown column type.
+var otherType = ens.NewGoType(ens.TypeOther, "")
+
+// intoGoType returns the go type of the column type by the builtin type mappings,
+// the unknown column type fallback to TypeOther with string.
+func intoGoType(columnType string) ens.GoType {
+	if m, ok := typeMapper.Match(columnType); ok {
+		return m.Type
 	}
-	panic(fmt.Sprintf("type (%v) not match in any way, need to add on (https://github.com/things-go/ormat/blob/main/driver/mysql/def.go)", columnType))
+	return otherType
+}
+
+// intoFieldType returns the go type of the column, whether the field need pointer and the type is customized,
+// the comment annotation `[@gotype: xxx]`, `[@jsontype: xxx]` take precedence over the user type mappings, then the builtin.
+func intoFieldType(col *schema.Column) (t ens.GoType, pointer, custom bool) {
+	var ok bool
+
+	t, pointer, ok = ens.MatchTypeMapping(col.Type.Raw, col.Type.Null)
+	if !ok {
+		t, pointer = intoGoType(col.Type.Raw), col.Type.Null
+	}
+	if v, p, ok := ens.AnnotatedGoType(insql.MustComment(col.Attrs), t.Type, col.Type.Null); ok {
+		return v, p, true
+	}
+	return t, pointer, false
 }
 
 type TableDef struct {
@@ -77,6 +109,8 @@ func NewTableDef(tb *schema.Table) ens.TableDef {
 
 func (d *TableDef) Table() *schema.Table { return d.tb }
 
+func (d *TableDef) Dialect() string { return ens.DialectMySQL }
+
 func (d *TableDef) PrimaryKey() ens.IndexDef {
 	if d.tb.PrimaryKey != nil {
 		return NewIndexDef(d.tb.PrimaryKey)
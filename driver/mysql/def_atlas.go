@@ -45,13 +45,26 @@ func intoColumnSql(col *schema.Column) string {
 }
 
 func intoIndexSql(index *schema.Index) string {
-	fields := insql.IndexPartColumnNames(index.Parts)
+	fields := make([]string, 0, len(index.Parts))
+	for _, part := range index.Parts {
+		switch {
+		case part.C != nil:
+			fields = append(fields, "`"+part.C.Name+"`")
+		case part.X != nil: // functional key part
+			if x, ok := part.X.(*schema.RawExpr); ok {
+				fields = append(fields, "("+x.X+")")
+			}
+		}
+	}
 	indexType := findIndexType(index.Attrs)
-	fieldList := "`" + strings.Join(fields, "`,`") + "`"
+	fieldList := strings.Join(fields, ",")
 	if insql.IndexEqual(index.Table.PrimaryKey, index) {
 		return fmt.Sprintf("PRIMARY KEY (%s) USING %s", fieldList, indexType)
 	} else if index.Unique {
 		return fmt.Sprintf("UNIQUE KEY `%s` (%s) USING %s", index.Name, fieldList, indexType)
+	} else if indexType == mysql.IndexTypeFullText || indexType == mysql.IndexTypeSpatial {
+		// FULLTEXT, SPATIAL 索引不支持 USING
+		return fmt.Sprintf("%s KEY `%s` (%s)", indexType, index.Name, fieldList)
 	} else {
 		return fmt.Sprintf("KEY `%s` (%s) USING %s", index.Name, fieldList, indexType)
 	}
@@ -60,10 +73,19 @@ func intoIndexSql(index *schema.Index) string {
 func intoForeignKeySql(fk *schema.ForeignKey) string {
 	columnNameList := "`" + strings.Join(insql.ColumnNames(fk.Columns), "`,`") + "`"
 	refColumnNameList := "`" + strings.Join(insql.ColumnNames(fk.RefColumns), "`,`") + "`"
-	return fmt.Sprintf(
-		"CONSTRAINT `%s` FOREIGN KEY (%s) REFERENCES `%s` (%s) ON DELETE %s ON UPDATE %s",
-		fk.Symbol, columnNameList, fk.RefTable.Name, refColumnNameList, fk.OnDelete, fk.OnUpdate,
-	)
+	b := &strings.Builder{}
+	b.Grow(128)
+	if fk.Symbol != "" {
+		fmt.Fprintf(b, "CONSTRAINT `%s` ", fk.Symbol)
+	}
+	fmt.Fprintf(b, "FOREIGN KEY (%s) REFERENCES `%s` (%s)", columnNameList, fk.RefTable.Name, refColumnNameList)
+	if fk.OnDelete != "" {
+		fmt.Fprintf(b, " ON DELETE %s", fk.OnDelete)
+	}
+	if fk.OnUpdate != "" {
+		fmt.Fprintf(b, " ON UPDATE %s", fk.OnUpdate)
+	}
+	return b.String()
 }
 
 func intoTableSql(tb *schema.Table) string {
@@ -71,10 +93,15 @@ func intoTableSql(tb *schema.Table) string {
 	b.Grow(256)
 	fmt.Fprintf(b, "CREATE TABLE `%s` (\n", tb.Name)
 
-	remain := len(tb.Columns) + len(tb.Indexes) + len(tb.ForeignKeys)
+	remain := len(tb.Columns) + len(tb.ForeignKeys)
 	if tb.PrimaryKey != nil {
 		remain++
 	}
+	for _, index := range tb.Indexes {
+		if !insql.IndexEqual(tb.PrimaryKey, index) {
+			remain++
+		}
+	}
 	suffixOrEmpty := func(r int) string {
 		if r == 0 {
 			return ""
@@ -98,10 +125,10 @@ func intoTableSql(tb *schema.Table) string {
 		fmt.Fprintf(b, "  %s%s\n", intoIndexSql(tb.PrimaryKey), suffix)
 	}
 	for _, index := range tb.Indexes {
-		remain--
 		if insql.IndexEqual(tb.PrimaryKey, index) { // ignore primary key, maybe include
 			continue
 		}
+		remain--
 		suffix := suffixOrEmpty(remain)
 		fmt.Fprintf(b, "  %s%s\n", intoIndexSql(index), suffix)
 	}
@@ -204,10 +231,9 @@ func intoGormTag(tb *schema.Table, col *schema.Column) string {
 			fmt.Fprintf(b, ";uniqueIndex:%s", val.Name)
 		} else {
 			fmt.Fprintf(b, ";index:%s", val.Name)
-			// 	mysql.IndexTypeFullText
-			// if v.IndexType == "FULLTEXT" {
-			// 	b.WriteString(",class:FULLTEXT")
-			// }
+			if t := findIndexType(val.Attrs); t == mysql.IndexTypeFullText || t == mysql.IndexTypeSpatial {
+				fmt.Fprintf(b, ",class:%s", t)
+			}
 		}
 		if len(val.Parts) > 1 {
 			priority, ok := insql.FindIndexPartSeq(val.Parts, col)
@@ -227,14 +253,16 @@ func intoSchema(tb *schema.Table) *ens.EntityDescriptor {
 	// * columns
 	fielders := make([]*ens.FieldDescriptor, 0, len(tb.Columns))
 	for _, col := range tb.Columns {
+		goType, goPointer, customType := intoFieldType(col)
 		fielders = append(fielders, &ens.FieldDescriptor{
 			ColumnName: col.Name,
 			Comment:    insql.MustComment(col.Attrs),
 			Nullable:   col.Type.Null,
 			Column:     NewColumnDef(col),
-			Type:       intoGoType(col.Type.Raw),
+			Type:       goType,
 			GoName:     utils.PascalCase(col.Name),
-			GoPointer:  col.Type.Null,
+			GoPointer:  goPointer,
+			CustomType: customType,
 			Tags:       []string{intoGormTag(tb, col)},
 		})
 	}
@@ -0,0 +1,89 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+)
+
+func Test_intoGoType(t *testing.T) {
+	tests := []struct {
+		columnType string
+		want       ens.GoType
+	}{
+		{"tinyint(1)", ens.BoolType()},
+		{"int unsigned", ens.Uint32Type()},
+		{"bigint", ens.Int64Type()},
+		{"varchar(64)", ens.StringType()},
+		{"json", ens.JSONRawMessageType()},
+		{"geometry", ens.StringType()},
+		{"point", ens.StringType()},
+		{"multipolygon", ens.StringType()},
+		{"vector(3)", ens.BytesType()},
+		{"unknown_type", ens.NewGoType(ens.TypeOther, "")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.columnType, func(t *testing.T) {
+			if got := intoGoType(tt.columnType); got != tt.want {
+				t.Errorf("intoGoType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TypeMapping(t *testing.T) {
+	t.Cleanup(ens.ResetTypeMapping)
+
+	pointType := ens.NewCustomGoType(ens.TypeOther, "orb.Point", "github.com/paulmach/orb")
+	nullDecimalType := ens.NewCustomGoType(ens.TypeDecimal, "decimal.NullDecimal", "github.com/shopspring/decimal")
+	err := ens.RegisterTypeMapping(
+		ens.TypeMapping{Pattern: `^(point)\b`, Type: pointType},
+		ens.TypeMapping{
+			Pattern:  `^(decimal)\b`,
+			Type:     ens.NewCustomGoType(ens.TypeDecimal, "decimal.Decimal", "github.com/shopspring/decimal"),
+			Nullable: &nullDecimalType,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ens.RegisterTypeMapping(ens.TypeMapping{Pattern: `^(point`}); err == nil {
+		t.Error("want error of the invalid pattern")
+	}
+
+	sql := "CREATE TABLE `place` (" +
+		"`id` bigint NOT NULL AUTO_INCREMENT," +
+		"`location` point DEFAULT NULL," +
+		"`price` decimal(10,2) DEFAULT NULL," +
+		"`area` multipolygon NOT NULL," +
+		"PRIMARY KEY (`id`)" +
+		") ENGINE=InnoDB;"
+	sc, err := (&SQL{}).InspectSchema(context.Background(), &driver.InspectOption{Data: sql})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := map[string]*ens.FieldDescriptor{}
+	for _, field := range sc.Entities[0].Fields {
+		fields[field.ColumnName] = field
+	}
+	tests := []struct {
+		column  string
+		want    ens.GoType
+		pointer bool
+	}{
+		{"location", pointType, true},
+		{"price", nullDecimalType, false},
+		{"area", ens.StringType(), false},
+	}
+	for _, tt := range tests {
+		field := fields[tt.column]
+		if field == nil {
+			t.Fatalf("column %s not found", tt.column)
+		}
+		if field.Type != tt.want || field.GoPointer != tt.pointer {
+			t.Errorf("%s: got %v(pointer: %v), want %v(pointer: %v)", tt.column, field.Type, field.GoPointer, tt.want, tt.pointer)
+		}
+	}
+}
@@ -8,4 +8,5 @@ func init() {
 	driver.RegisterDriver(driver.Mysql, &MySQL{})
 	driver.RegisterDriver(driver.FileMysql, &SQL{})
 	driver.RegisterDriver(driver.FileMysqlTidb, &SQLTidb{})
+	driver.RegisterDriver(driver.MigrateMysql, &Migrate{})
 }
@@ -0,0 +1,35 @@
+package mysql
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+	"github.com/things-go/ens/internal/insql"
+	"github.com/things-go/ens/internal/migrate"
+)
+
+var _ driver.Driver = (*Migrate)(nil)
+
+// Migrate rebuild the schema from the versioned migration directory,
+// url like `migrate+mysql://./migrations`.
+type Migrate struct{}
+
+// InspectSchema implements driver.Driver.
+func (m *Migrate) InspectSchema(ctx context.Context, arg *driver.InspectOption) (*ens.Schema, error) {
+	dir := strings.TrimPrefix(arg.URL, driver.MigrateMysql+"://")
+	data, err := migrate.ReadUpSQL(dir)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := (&SQLTidb{}).InspectSchema(ctx, &driver.InspectOption{Data: data})
+	if err != nil {
+		return nil, err
+	}
+	sc.Entities = slices.DeleteFunc(sc.Entities, func(v *ens.EntityDescriptor) bool {
+		return !insql.MatchTable(v.Name, arg.Tables, arg.Exclude)
+	})
+	return sc, nil
+}
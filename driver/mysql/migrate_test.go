@@ -0,0 +1,89 @@
+package mysql
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/things-go/ens/driver"
+)
+
+func writeMigrations(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func Test_Migrate(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name: "golang-migrate",
+			files: map[string]string{
+				"000001_create_user.up.sql":   "CREATE TABLE `user` (`id` bigint NOT NULL AUTO_INCREMENT, PRIMARY KEY (`id`));",
+				"000001_create_user.down.sql": "DROP TABLE `user`;",
+				"000002_add_name.up.sql":      "ALTER TABLE `user` ADD COLUMN `name` varchar(64) NOT NULL DEFAULT '';",
+				"000002_add_name.down.sql":    "ALTER TABLE `user` DROP COLUMN `name`;",
+				"000010_add_index.up.sql":     "CREATE INDEX `idx_name` ON `user` (`name`);\nCREATE TABLE `tmp` (`id` bigint NOT NULL);",
+			},
+		},
+		{
+			name: "atlas",
+			files: map[string]string{
+				"20240101000000_init.sql":     "CREATE TABLE `user` (`id` bigint NOT NULL AUTO_INCREMENT, PRIMARY KEY (`id`));",
+				"20240102000000_name.sql":     "ALTER TABLE `user` ADD COLUMN `name` varchar(64) NOT NULL DEFAULT ''",
+				"202401030000000_index.sql":   "CREATE INDEX `idx_name` ON `user` (`name`);\nCREATE TABLE `tmp` (`id` bigint NOT NULL);",
+				"atlas.sum":                   "h1:xxx",
+				"README.md":                   "# migrations",
+				"20240101000000_init.sql.bak": "DROP TABLE `user`;",
+			},
+		},
+		{
+			name: "goose",
+			files: map[string]string{
+				"00001_init.sql": "-- +goose Up\n-- +goose StatementBegin\nCREATE TABLE `user` (`id` bigint NOT NULL AUTO_INCREMENT, PRIMARY KEY (`id`));\n-- +goose StatementEnd\n\n-- +goose Down\nDROP TABLE `user`;\n",
+				"00002_name.sql": "-- +goose Up\nALTER TABLE `user` ADD COLUMN `name` varchar(64) NOT NULL DEFAULT '';\nCREATE INDEX `idx_name` ON `user` (`name`);\nCREATE TABLE `tmp` (`id` bigint NOT NULL);\n-- +goose Down\nDROP INDEX `idx_name` ON `user`;\nALTER TABLE `user` DROP COLUMN `name`;\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeMigrations(t, tt.files)
+			d := &Migrate{}
+			arg := &driver.InspectOption{URL: driver.MigrateMysql + "://" + dir}
+			arg.Exclude = []string{"tm*"}
+			sc, err := d.InspectSchema(context.Background(), arg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sc.Entities) != 1 {
+				t.Fatalf("entities count = %d, want 1", len(sc.Entities))
+			}
+			user := sc.Entities[0]
+			if len(user.Fields) != 2 || len(user.Indexes) != 2 {
+				t.Fatalf("fields = %d, indexes = %d, want 2, 2", len(user.Fields), len(user.Indexes))
+			}
+			if got, want := user.Fields[1].Tags[0], `gorm:"column:name;type:varchar(64);not null;default:'';index:idx_name"`; got != want {
+				t.Errorf("gorm tag = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_Migrate_DuplicateVersion(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"1_a.sql":  "CREATE TABLE `a` (`id` bigint NOT NULL);",
+		"01_b.sql": "CREATE TABLE `b` (`id` bigint NOT NULL);",
+	})
+	_, err := (&Migrate{}).InspectSchema(context.Background(), &driver.InspectOption{URL: driver.MigrateMysql + "://" + dir})
+	if err == nil {
+		t.Fatal("want duplicate version error")
+	}
+}
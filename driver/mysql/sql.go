@@ -2,8 +2,9 @@ package mysql
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -17,37 +18,169 @@ import (
 
 var _ driver.Driver = (*SQL)(nil)
 
+// foreign key clause in create table statement, sqlparser not support it, so we extract it by ourselves.
+// [CONSTRAINT [symbol]] FOREIGN KEY [index_name] (col_name,...) REFERENCES tbl_name (col_name,...) [ON DELETE reference_option] [ON UPDATE reference_option]
+var foreignKeyRegexp = regexp.MustCompile("(?i),\\s*(?:CONSTRAINT(?:\\s+`?([^`\\s(]+)`?)?\\s+)?" +
+	"FOREIGN\\s+KEY(?:\\s+`?[^`\\s(]+`?)?\\s*\\(([^)]+)\\)\\s*" +
+	"REFERENCES\\s+(?:`?[^`\\s(.]+`?\\.)?`?([^`\\s(.]+)`?\\s*\\(([^)]+)\\)" +
+	"((?:\\s+ON\\s+(?:DELETE|UPDATE)\\s+(?:RESTRICT|CASCADE|SET\\s+NULL|NO\\s+ACTION|SET\\s+DEFAULT))*)")
+
+var referenceOptionRegexp = regexp.MustCompile(`(?i)ON\s+(DELETE|UPDATE)\s+(RESTRICT|CASCADE|SET\s+NULL|NO\s+ACTION|SET\s+DEFAULT)`)
+
+// the `CREATE TABLE` statement, the other ddl(like `CREATE DATABASE ... /*!40100 ... */`) maybe not supported by sqlparser.
+var createTableRegexp = regexp.MustCompile(`(?i)^CREATE\s+(?:TEMPORARY\s+)?TABLE\b`)
+
+// foreignKeyDef foreign key definition which not resolved.
+type foreignKeyDef struct {
+	Symbol     string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+	OnUpdate   schema.ReferenceOption
+	OnDelete   schema.ReferenceOption
+}
+
 type SQL struct{}
 
 // InspectSchema implements driver.Driver.
 func (sq *SQL) InspectSchema(ctx context.Context, arg *driver.InspectOption) (*ens.Schema, error) {
-	table, err := sq.inspectSchema(ctx, arg)
+	tables, err := sq.inspectSchema(ctx, arg)
 	if err != nil {
 		return nil, err
 	}
+	entities := make([]*ens.EntityDescriptor, 0, len(tables))
+	for _, table := range tables {
+		entities = append(entities, intoSchema(table))
+	}
 	return &ens.Schema{
 		Name:     "",
-		Entities: []*ens.EntityDescriptor{intoSchema(table)},
+		Entities: entities,
 	}, nil
 }
 
-func (sq *SQL) inspectSchema(_ context.Context, arg *driver.InspectOption) (*schema.Table, error) {
-	statement, err := sqlparser.Parse(arg.Data)
+// inspectSchema parse all `CREATE TABLE` statement, other statement will be ignored.
+func (sq *SQL) inspectSchema(_ context.Context, arg *driver.InspectOption) ([]*schema.Table, error) {
+	pieces, err := sqlparser.SplitStatementToPieces(arg.Data)
 	if err != nil {
 		return nil, err
 	}
-	switch stmt := statement.(type) {
-	case *sqlparser.DDL:
-		if stmt.Action != sqlparser.CreateStr {
-			return nil, errors.New("不是创建表语句")
+	tables := make([]*schema.Table, 0, len(pieces))
+	foreignKeys := make(map[*schema.Table][]*foreignKeyDef)
+	for _, piece := range pieces {
+		if sqlparser.Preview(piece) != sqlparser.StmtDDL ||
+			!createTableRegexp.MatchString(sqlparser.StripLeadingComments(piece)) {
+			continue
+		}
+		piece, fks, err := extractForeignKeys(piece)
+		if err != nil {
+			return nil, err
+		}
+		statement, err := sqlparser.Parse(piece)
+		if err != nil {
+			return nil, err
+		}
+		stmt, ok := statement.(*sqlparser.DDL)
+		if !ok || stmt.Action != sqlparser.CreateStr || stmt.NewName.IsEmpty() {
+			continue
 		}
 		if stmt.TableSpec == nil {
-			return nil, errors.New("未解析到任何字段")
+			return nil, fmt.Errorf("表(%s)未解析到任何字段", stmt.NewName.Name.String())
 		}
-		return parseSqlTable(stmt)
-	default:
-		return nil, errors.New("不是DDL语句")
+		table, err := parseSqlTable(stmt)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+		foreignKeys[table] = fks
+	}
+	for _, table := range tables {
+		if err = resolveForeignKeys(table, tables, foreignKeys[table]); err != nil {
+			return nil, err
+		}
+	}
+	return tables, nil
+}
+
+// extractForeignKeys extract the foreign key clause from create table statement,
+// return the statement without foreign key clause.
+func extractForeignKeys(sql string) (string, []*foreignKeyDef, error) {
+	matches := foreignKeyRegexp.FindAllStringSubmatch(sql, -1)
+	if len(matches) == 0 {
+		return sql, nil, nil
+	}
+	fks := make([]*foreignKeyDef, 0, len(matches))
+	for _, match := range matches {
+		fk := &foreignKeyDef{
+			Symbol:     match[1],
+			Columns:    splitColumnNames(match[2]),
+			RefTable:   match[3],
+			RefColumns: splitColumnNames(match[4]),
+		}
+		if len(fk.Columns) != len(fk.RefColumns) {
+			return "", nil, fmt.Errorf("foreign key(%s) columns mismatch reference columns", fk.Symbol)
+		}
+		for _, opt := range referenceOptionRegexp.FindAllStringSubmatch(match[5], -1) {
+			option := schema.ReferenceOption(strings.ToUpper(strings.Join(strings.Fields(opt[2]), " ")))
+			if strings.EqualFold(opt[1], "DELETE") {
+				fk.OnDelete = option
+			} else {
+				fk.OnUpdate = option
+			}
+		}
+		fks = append(fks, fk)
+	}
+	return foreignKeyRegexp.ReplaceAllString(sql, ""), fks, nil
+}
+
+func splitColumnNames(s string) []string {
+	names := strings.Split(s, ",")
+	for i, name := range names {
+		names[i] = strings.Trim(strings.TrimSpace(name), "`")
+	}
+	return names
+}
+
+// resolveForeignKeys resolve foreign keys to the table, if the reference table not in the tables,
+// it will be created with the reference columns only.
+func resolveForeignKeys(table *schema.Table, tables []*schema.Table, fks []*foreignKeyDef) error {
+	for _, v := range fks {
+		columns := make([]*schema.Column, 0, len(v.Columns))
+		for _, name := range v.Columns {
+			col, ok := table.Column(name)
+			if !ok {
+				return fmt.Errorf("foreign key('%s') column '%s' doesn't exist in table '%s'", v.Symbol, name, table.Name)
+			}
+			columns = append(columns, col)
+		}
+		refTable := schema.NewTable(v.RefTable)
+		for _, tb := range tables {
+			if tb.Name == v.RefTable {
+				refTable = tb
+				break
+			}
+		}
+		refColumns := make([]*schema.Column, 0, len(v.RefColumns))
+		for _, name := range v.RefColumns {
+			col, ok := refTable.Column(name)
+			if !ok {
+				if slices.Contains(tables, refTable) {
+					return fmt.Errorf("foreign key('%s') reference column '%s' doesn't exist in table '%s'", v.Symbol, name, refTable.Name)
+				}
+				col = schema.NewColumn(name)
+				refTable.AddColumns(col)
+			}
+			refColumns = append(refColumns, col)
+		}
+		table.AddForeignKeys(
+			schema.NewForeignKey(v.Symbol).
+				AddColumns(columns...).
+				SetRefTable(refTable).
+				AddRefColumns(refColumns...).
+				SetOnUpdate(v.OnUpdate).
+				SetOnDelete(v.OnDelete),
+		)
 	}
+	return nil
 }
 
 func parseSqlTable(stmt *sqlparser.DDL) (*schema.Table, error) {
@@ -100,7 +233,12 @@ func parseSqlColumnDefinition(col *sqlparser.ColumnDefinition) (*schema.Column,
 	coldef := schema.NewColumn(col.Name.String())
 	colType := col.Type
 	if colType.Default != nil {
-		coldef.Default = &schema.Literal{V: string(colType.Default.Val)}
+		switch v := string(colType.Default.Val); {
+		case colType.Default.Type != sqlparser.ValArg:
+			coldef.Default = &schema.Literal{V: v}
+		case !strings.EqualFold(v, "null"): // DEFAULT NULL and CURRENT_TIMESTAMP are ValArg
+			coldef.Default = &schema.RawExpr{X: v}
+		}
 	}
 	if colType.OnUpdate != nil {
 		coldef.AddAttrs(&mysql.OnUpdate{
@@ -306,10 +444,14 @@ func parseSqlColumnDefinition(col *sqlparser.ColumnDefinition) (*schema.Column,
 		}
 	case mysql.TypeEnum:
 		// `^(enum)\b[(](.)+[)]`
+		values := make([]string, 0, len(colType.EnumValues))
+		for _, v := range colType.EnumValues { // sqlparser keep the quote, like 'a'
+			values = append(values, strings.Trim(v, "'"))
+		}
 		coldef.Type = &schema.ColumnType{
 			Type: &schema.EnumType{
 				T:      colType.Type,
-				Values: colType.EnumValues,
+				Values: values,
 			},
 			Raw:  "enum(" + strings.Join(colType.EnumValues, ",") + ")",
 			Null: !bool(colType.NotNull),
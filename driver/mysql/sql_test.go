@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/things-go/ens/driver"
@@ -58,3 +59,160 @@ func Test_SQL_Parse(t *testing.T) {
 	}
 	fmt.Println(value.Entities[0])
 }
+
+func Test_SQL_Parse_MultiStatement(t *testing.T) {
+	sql := "/*!40101 SET NAMES utf8mb4 */;\n" +
+		"CREATE DATABASE /*!32312 IF NOT EXISTS*/ `test` /*!40100 DEFAULT CHARACTER SET utf8mb4 */ /*!80016 DEFAULT ENCRYPTION='N' */;\n" +
+		"USE `test`;\n" +
+		"-- Table structure for table `user`\n" +
+		"DROP TABLE IF EXISTS `user`;\n" +
+		"CREATE TABLE `user` (\n" +
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(64) NOT NULL DEFAULT '' COMMENT '名称;昵称',\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8mb4;\n" +
+		"LOCK TABLES `user` WRITE;\n" +
+		"INSERT INTO `user` VALUES (1,'a;b');\n" +
+		"UNLOCK TABLES;\n" +
+		"DROP TABLE IF EXISTS `post`;\n" +
+		"CREATE TABLE `post` (\n" +
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n" +
+		"  `user_id` bigint NOT NULL,\n" +
+		"  `category_id` bigint NOT NULL,\n" +
+		"  `editor_id` bigint DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `post_ibfk_1` (`user_id`),\n" +
+		"  CONSTRAINT `post_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE NO ACTION,\n" +
+		"  CONSTRAINT `post_ibfk_2` FOREIGN KEY (`category_id`) REFERENCES `category` (`id`),\n" +
+		"  FOREIGN KEY (`editor_id`) REFERENCES `user` (`id`) ON DELETE SET NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n"
+
+	d := &SQL{}
+	value, err := d.InspectSchema(context.Background(), &driver.InspectOption{Data: sql})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(value.Entities) != 2 {
+		t.Fatalf("entities count = %d, want 2", len(value.Entities))
+	}
+	if got := value.Entities[0].Fields[1].Comment; got != "名称;昵称" {
+		t.Errorf("comment = %v, want 名称;昵称", got)
+	}
+	post := value.Entities[1]
+	if len(post.ForeignKeys) != 3 {
+		t.Fatalf("foreign keys count = %d, want 3", len(post.ForeignKeys))
+	}
+	fk := post.ForeignKeys[0]
+	if fk.Symbol != "post_ibfk_1" || fk.RefTable != "user" || fk.Columns[0] != "user_id" || fk.RefColumns[0] != "id" ||
+		fk.OnDelete != "CASCADE" || fk.OnUpdate != "NO ACTION" {
+		t.Errorf("unexpected foreign key: %+v", fk)
+	}
+	if fk = post.ForeignKeys[1]; fk.RefTable != "category" || fk.RefColumns[0] != "id" {
+		t.Errorf("unexpected foreign key: %+v", fk)
+	}
+	definition := post.Table.Definition()
+	for _, want := range []string{
+		"  CONSTRAINT `post_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE ON UPDATE NO ACTION,\n",
+		"  CONSTRAINT `post_ibfk_2` FOREIGN KEY (`category_id`) REFERENCES `category` (`id`),\n",
+		"  FOREIGN KEY (`editor_id`) REFERENCES `user` (`id`) ON DELETE SET NULL\n",
+	} {
+		if !strings.Contains(definition, want) {
+			t.Errorf("definition missing foreign key %q, got:\n%s", want, definition)
+		}
+	}
+}
+
+func Test_Tidb_SQL_Parse_Alter(t *testing.T) {
+	sql := "CREATE TABLE `user` (" +
+		"`id` bigint NOT NULL AUTO_INCREMENT," +
+		"`name` varchar(64) NOT NULL DEFAULT ''," +
+		"`age` int NOT NULL," +
+		"PRIMARY KEY (`id`)," +
+		"KEY `idx_name_age` (`name`,`age`)" +
+		") ENGINE=InnoDB;\n" +
+		"CREATE TABLE `tmp` (`id` bigint NOT NULL);\n" +
+		"CREATE TABLE `post` (`id` bigint NOT NULL, `uid` bigint NOT NULL, PRIMARY KEY (`id`));\n" +
+		"ALTER TABLE `user` ADD COLUMN `email` varchar(128) NOT NULL DEFAULT '' COMMENT '邮箱' AFTER `name`, ADD UNIQUE KEY `uk_email` (`email`);\n" +
+		"ALTER TABLE `user` DROP COLUMN `age`, MODIFY COLUMN `name` varchar(128) NOT NULL COMMENT '名称' FIRST;\n" +
+		"ALTER TABLE `user` CHANGE `email` `mail` varchar(255) NOT NULL, ALTER COLUMN `mail` SET DEFAULT 'x', COMMENT = '用户表';\n" +
+		"CREATE INDEX `idx_uid` ON `post` (`uid`);\n" +
+		"ALTER TABLE `post` ADD CONSTRAINT `fk_post_user` FOREIGN KEY (`uid`) REFERENCES `user` (`id`) ON DELETE CASCADE;\n" +
+		"ALTER TABLE `user` RENAME INDEX `uk_email` TO `uk_mail`;\n" +
+		"RENAME TABLE `user` TO `users`;\n" +
+		"DROP INDEX `idx_uid` ON `post`;\n" +
+		"DROP TABLE `tmp`;\n" +
+		"INSERT INTO `post` VALUES (1, 1);\n"
+
+	d := &SQLTidb{}
+	value, err := d.InspectSchema(context.Background(), &driver.InspectOption{Data: sql})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(value.Entities) != 2 {
+		t.Fatalf("entities count = %d, want 2", len(value.Entities))
+	}
+	users, post := value.Entities[0], value.Entities[1]
+	if users.Name != "users" || users.Comment != "用户表" {
+		t.Errorf("unexpected table: %s(%s)", users.Name, users.Comment)
+	}
+	gotColumns := make([]string, 0, len(users.Fields))
+	for _, field := range users.Fields {
+		gotColumns = append(gotColumns, field.ColumnName)
+	}
+	if got, want := strings.Join(gotColumns, ","), "name,id,mail"; got != want {
+		t.Errorf("columns = %v, want %v", got, want)
+	}
+	if got, want := users.Fields[0].Tags[0], `gorm:"column:name;type:varchar(128);not null;index:idx_name_age;comment:名称"`; got != want {
+		t.Errorf("gorm tag = %v, want %v", got, want)
+	}
+	if got, want := users.Fields[2].Tags[0], `gorm:"column:mail;type:varchar(255);not null;default:x;uniqueIndex:uk_mail"`; got != want {
+		t.Errorf("gorm tag = %v, want %v", got, want)
+	}
+	if len(post.Indexes) != 1 || len(post.ForeignKeys) != 1 {
+		t.Fatalf("post indexes = %d, foreign keys = %d, want 1, 1", len(post.Indexes), len(post.ForeignKeys))
+	}
+	if fk := post.ForeignKeys[0]; fk.RefTable != "users" || fk.OnDelete != "CASCADE" {
+		t.Errorf("unexpected foreign key: %+v", fk)
+	}
+}
+
+func Test_Tidb_SQL_Parse_ForwardReference(t *testing.T) {
+	sql := "CREATE TABLE `post` (" +
+		"`id` bigint NOT NULL," +
+		"`uid` bigint NOT NULL," +
+		"`title` varchar(64) NOT NULL," +
+		"PRIMARY KEY (`id`)," +
+		"CONSTRAINT `fk_post_user` FOREIGN KEY (`uid`) REFERENCES `user` (`id`)" +
+		");\n" +
+		"CREATE TABLE `user` (`id` bigint NOT NULL, PRIMARY KEY (`id`));\n" +
+		"ALTER TABLE `post` ADD INDEX ((lower(`title`))), ADD INDEX (`title`), ADD INDEX (`title`, `uid`);\n"
+
+	d := &SQLTidb{}
+	value, err := d.InspectSchema(context.Background(), &driver.InspectOption{Data: sql})
+	if err != nil {
+		t.Fatal(err)
+	}
+	post, user := value.Entities[0].Table.Table(), value.Entities[1].Table.Table()
+	if fk := post.ForeignKeys[0]; fk.RefTable != user || fk.RefColumns[0] != user.Columns[0] {
+		t.Errorf("foreign key should reference the table created later, got: %+v", fk.RefTable)
+	}
+	definition := value.Entities[0].Table.Definition()
+	for _, want := range []string{
+		"KEY `functional_index` ((lower(`title`))) USING BTREE",
+		"KEY `title` (`title`) USING BTREE",
+		"KEY `title_2` (`title`,`uid`) USING BTREE",
+	} {
+		if !strings.Contains(definition, want) {
+			t.Errorf("definition missing %q, got:\n%s", want, definition)
+		}
+	}
+
+	// the reference column doesn't exist in the table created later.
+	_, err = d.InspectSchema(context.Background(), &driver.InspectOption{
+		Data: "CREATE TABLE `post` (`uid` bigint NOT NULL, CONSTRAINT `fk` FOREIGN KEY (`uid`) REFERENCES `user` (`uid`));\n" +
+			"CREATE TABLE `user` (`id` bigint NOT NULL);\n",
+	})
+	if err == nil {
+		t.Error("want error of the reference column doesn't exist")
+	}
+}
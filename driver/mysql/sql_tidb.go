@@ -22,6 +22,8 @@ import (
 type SQLTidb struct{}
 
 // InspectSchema implements driver.Driver.
+// It replays the statements in order, `CREATE TABLE`, `ALTER TABLE`, `CREATE/DROP INDEX`,
+// `RENAME TABLE` and `DROP TABLE` will be applied, other statement will be ignored.
 func (st *SQLTidb) InspectSchema(_ context.Context, arg *driver.InspectOption) (*ens.Schema, error) {
 	pr := parser.New()
 	stmts, _, err := pr.ParseSQL(arg.Data)
@@ -29,16 +31,17 @@ func (st *SQLTidb) InspectSchema(_ context.Context, arg *driver.InspectOption) (
 		return nil, err
 	}
 
-	entities := make([]*ens.EntityDescriptor, 0, len(stmts))
+	ts := &tidbTables{}
 	for _, stmt := range stmts {
-		createStmt, ok := stmt.(*ast.CreateTableStmt)
-		if !ok {
-			continue
-		}
-		table, err := parserCreateTableStmtTable(createStmt)
-		if err != nil {
+		if err = ts.apply(stmt); err != nil {
 			return nil, err
 		}
+	}
+	if err = ts.resolveForeignKeys(); err != nil {
+		return nil, err
+	}
+	entities := make([]*ens.EntityDescriptor, 0, len(ts.tables))
+	for _, table := range ts.tables {
 		entities = append(entities, intoSchema(table))
 	}
 	return &ens.Schema{
@@ -52,18 +55,7 @@ func parserCreateTableStmtTable(stmt *ast.CreateTableStmt) (*schema.Table, error
 
 	//* table
 	// ENGINE=InnoDB default charset=utf8mb4 collate=utf8mb4_general_ci comment='我是注释'
-	for _, option := range stmt.Options {
-		switch option.Tp { // nolint: exhaustive
-		case ast.TableOptionEngine:
-			table.AddAttrs(&mysql.Engine{V: option.StrValue, Default: false})
-		case ast.TableOptionCharset:
-			table.AddAttrs(&schema.Charset{V: option.StrValue})
-		case ast.TableOptionCollate:
-			table.AddAttrs(&schema.Collation{V: option.StrValue})
-		case ast.TableOptionComment:
-			table.AddAttrs(&schema.Comment{Text: option.StrValue})
-		}
-	}
+	parserTableOptions(table, stmt.Options)
 	//* columns
 	columns := make([]*schema.Column, 0, len(stmt.Cols))
 	for _, col := range stmt.Cols {
@@ -91,6 +83,21 @@ func parserCreateTableStmtTable(stmt *ast.CreateTableStmt) (*schema.Table, error
 	return table, nil
 }
 
+func parserTableOptions(table *schema.Table, options []*ast.TableOption) {
+	for _, option := range options {
+		switch option.Tp { // nolint: exhaustive
+		case ast.TableOptionEngine:
+			schema.ReplaceOrAppend(&table.Attrs, &mysql.Engine{V: option.StrValue, Default: false})
+		case ast.TableOptionCharset:
+			schema.ReplaceOrAppend(&table.Attrs, &schema.Charset{V: option.StrValue})
+		case ast.TableOptionCollate:
+			schema.ReplaceOrAppend(&table.Attrs, &schema.Collation{V: option.StrValue})
+		case ast.TableOptionComment:
+			schema.ReplaceOrAppend(&table.Attrs, &schema.Comment{Text: option.StrValue})
+		}
+	}
+}
+
 func parserCreateTableStmtColumn(col *ast.ColumnDef) (*schema.Column, error) {
 	coldef := schema.NewColumn(col.Name.Name.L)
 	nullable := true
@@ -102,8 +109,10 @@ func parserCreateTableStmtColumn(col *ast.ColumnDef) (*schema.Column, error) {
 			coldef.AddAttrs(&mysql.AutoIncrement{})
 		case ast.ColumnOptionDefaultValue:
 			val := formatExprNode(opt.Expr)
-			if !strings.EqualFold(val, "null") {
-				coldef.Default = &schema.Literal{V: strings.Trim(formatExprNode(opt.Expr), `"`)}
+			if _, ok := opt.Expr.(*ast.FuncCallExpr); ok { // like CURRENT_TIMESTAMP
+				coldef.Default = &schema.RawExpr{X: val}
+			} else if !strings.EqualFold(val, "null") {
+				coldef.Default = &schema.Literal{V: strings.Trim(val, `"`)}
 			}
 
 		case ast.ColumnOptionComment:
@@ -243,19 +252,16 @@ func parserCreateTableStmtColumn(col *ast.ColumnDef) (*schema.Column, error) {
 		// `^(timestamp)\b([(]\d+[)])?`
 		// `^(time)\b([(]\d+[)])?`
 		// `^(year)\b([(]\d+[)])?`
-		var precision, scale *int
+		// flen is the display width, decimal is the fractional seconds precision.
+		var precision *int
 
-		if length != -1 {
-			precision = &length
-		}
-		if decimal != -1 {
-			scale = &decimal
+		if decimal > 0 {
+			precision = &decimal
 		}
 		coldef.Type = &schema.ColumnType{
 			Type: &schema.TimeType{
 				T:         ftTypeValue,
 				Precision: precision,
-				Scale:     scale,
 			},
 			Raw:  ftTypeRaw,
 			Null: nullable,
@@ -320,20 +326,21 @@ func parseCreateTableStmtIndex(table *schema.Table, idx *ast.Constraint, columns
 		indexType = idx.Option.Tp.String()
 	}
 
-	cols := make([]*schema.Column, 0, len(idx.Keys))
+	index := schema.NewIndex(indexName).
+		SetUnique(unique).
+		AddAttrs(&mysql.IndexType{T: indexType})
 	for _, idxCol := range idx.Keys {
+		if idxCol.Column == nil { // functional key part, like ((lower(`name`)))
+			index.AddExprs(&schema.RawExpr{X: formatExprNode(idxCol.Expr)})
+			continue
+		}
 		columnName := idxCol.Column.String()
 		col, ok := insql.FindColumn(columns, columnName)
-		if ok {
-			cols = append(cols, col)
-		} else {
+		if !ok {
 			return nil, fmt.Errorf("Key('%s') column '%s' doesn't exist in table '%s'", indexName, columnName, table.Name)
 		}
+		index.AddColumns(col)
 	}
-	index := schema.NewIndex(indexName).
-		SetUnique(unique).
-		AddAttrs(&mysql.IndexType{T: indexType}).
-		AddColumns(cols...)
 	if isPk {
 		table.SetPrimaryKey(index)
 	}
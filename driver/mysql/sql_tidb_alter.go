@@ -0,0 +1,442 @@
+package mysql
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/sql/schema"
+	"github.com/pingcap/tidb/parser/ast"
+)
+
+// tidbTables the in-memory tables, statements are applied on it in order.
+type tidbTables struct {
+	tables []*schema.Table
+	// stubs the reference tables of the foreign keys which not created yet when the foreign key added.
+	stubs []*schema.Table
+}
+
+func (ts *tidbTables) find(name string) (*schema.Table, bool) {
+	for _, tb := range ts.tables {
+		if tb.Name == name {
+			return tb, true
+		}
+	}
+	return nil, false
+}
+
+func (ts *tidbTables) mustFind(name string) (*schema.Table, error) {
+	tb, ok := ts.find(name)
+	if !ok {
+		return nil, fmt.Errorf("table '%s' doesn't exist", name)
+	}
+	return tb, nil
+}
+
+func (ts *tidbTables) apply(stmt ast.StmtNode) error {
+	switch stmt := stmt.(type) {
+	case *ast.CreateTableStmt:
+		return ts.createTable(stmt)
+	case *ast.AlterTableStmt:
+		return ts.alterTable(stmt)
+	case *ast.CreateIndexStmt:
+		tb, err := ts.mustFind(stmt.Table.Name.L)
+		if err != nil {
+			return err
+		}
+		if _, ok := findIndex(tb, stmt.IndexName); ok && stmt.IfNotExists {
+			return nil
+		}
+		tp := ast.ConstraintIndex
+		if stmt.KeyType == ast.IndexKeyTypeUnique {
+			tp = ast.ConstraintUniqIndex
+		}
+		return ts.addConstraint(tb, &ast.Constraint{
+			Tp:     tp,
+			Name:   stmt.IndexName,
+			Keys:   stmt.IndexPartSpecifications,
+			Option: stmt.IndexOption,
+		})
+	case *ast.DropIndexStmt:
+		tb, err := ts.mustFind(stmt.Table.Name.L)
+		if err != nil {
+			return err
+		}
+		return dropIndex(tb, stmt.IndexName, stmt.IfExists)
+	case *ast.RenameTableStmt:
+		for _, v := range stmt.TableToTables {
+			if err := ts.renameTable(v.OldTable.Name.L, v.NewTable.Name.L); err != nil {
+				return err
+			}
+		}
+	case *ast.DropTableStmt:
+		if stmt.IsView {
+			return nil
+		}
+		for _, v := range stmt.Tables {
+			tb, ok := ts.find(v.Name.L)
+			if !ok {
+				if stmt.IfExists {
+					continue
+				}
+				return fmt.Errorf("table '%s' doesn't exist", v.Name.L)
+			}
+			ts.tables = slices.DeleteFunc(ts.tables, func(t *schema.Table) bool { return t == tb })
+		}
+	}
+	return nil
+}
+
+func (ts *tidbTables) createTable(stmt *ast.CreateTableStmt) error {
+	old, exist := ts.find(stmt.Table.Name.L)
+	if exist && stmt.IfNotExists {
+		return nil
+	}
+	table, err := parserCreateTableStmtTable(stmt)
+	if err != nil {
+		return err
+	}
+	if exist {
+		ts.tables[slices.Index(ts.tables, old)] = table
+	} else {
+		ts.tables = append(ts.tables, table)
+	}
+	for _, constraint := range stmt.Constraints {
+		if constraint.Tp != ast.ConstraintForeignKey {
+			continue
+		}
+		if err = ts.addForeignKey(table, constraint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ts *tidbTables) alterTable(stmt *ast.AlterTableStmt) error {
+	tb, err := ts.mustFind(stmt.Table.Name.L)
+	if err != nil {
+		return err
+	}
+	for _, spec := range stmt.Specs {
+		switch spec.Tp { // nolint: exhaustive
+		case ast.AlterTableOption:
+			parserTableOptions(tb, spec.Options)
+		case ast.AlterTableAddColumns:
+			for _, colDef := range spec.NewColumns {
+				if _, ok := tb.Column(colDef.Name.Name.L); ok {
+					if spec.IfNotExists {
+						continue
+					}
+					return fmt.Errorf("duplicate column '%s' in table '%s'", colDef.Name.Name.L, tb.Name)
+				}
+				col, err := parserCreateTableStmtColumn(colDef)
+				if err != nil {
+					return err
+				}
+				tb.AddColumns(col)
+				if err = moveColumn(tb, col, spec.Position); err != nil {
+					return err
+				}
+			}
+			for _, constraint := range spec.NewConstraints {
+				if err = ts.addConstraint(tb, constraint); err != nil {
+					return err
+				}
+			}
+		case ast.AlterTableDropColumn:
+			col, ok := tb.Column(spec.OldColumnName.Name.L)
+			if !ok {
+				if spec.IfExists {
+					continue
+				}
+				return fmt.Errorf("column '%s' doesn't exist in table '%s'", spec.OldColumnName.Name.L, tb.Name)
+			}
+			dropColumn(tb, col)
+		case ast.AlterTableModifyColumn, ast.AlterTableChangeColumn:
+			name := spec.NewColumns[0].Name.Name.L
+			if spec.Tp == ast.AlterTableChangeColumn {
+				name = spec.OldColumnName.Name.L
+			}
+			col, ok := tb.Column(name)
+			if !ok {
+				if spec.IfExists {
+					continue
+				}
+				return fmt.Errorf("column '%s' doesn't exist in table '%s'", name, tb.Name)
+			}
+			newCol, err := parserCreateTableStmtColumn(spec.NewColumns[0])
+			if err != nil {
+				return err
+			}
+			// keep the column pointer, the indexes and foreign keys reference it.
+			newCol.Indexes, newCol.ForeignKeys = col.Indexes, col.ForeignKeys
+			*col = *newCol
+			if err = moveColumn(tb, col, spec.Position); err != nil {
+				return err
+			}
+		case ast.AlterTableRenameColumn:
+			col, ok := tb.Column(spec.OldColumnName.Name.L)
+			if !ok {
+				return fmt.Errorf("column '%s' doesn't exist in table '%s'", spec.OldColumnName.Name.L, tb.Name)
+			}
+			col.Name = spec.NewColumnName.Name.L
+		case ast.AlterTableAlterColumn:
+			colDef := spec.NewColumns[0]
+			col, ok := tb.Column(colDef.Name.Name.L)
+			if !ok {
+				return fmt.Errorf("column '%s' doesn't exist in table '%s'", colDef.Name.Name.L, tb.Name)
+			}
+			// SET DEFAULT with a default value option, DROP DEFAULT without any option.
+			col.Default = nil
+			for _, opt := range colDef.Options {
+				if opt.Expr == nil {
+					continue
+				}
+				if val := formatExprNode(opt.Expr); !strings.EqualFold(val, "null") {
+					col.Default = &schema.Literal{V: strings.Trim(val, `"`)}
+				}
+			}
+		case ast.AlterTableAddConstraint:
+			if err = ts.addConstraint(tb, spec.Constraint); err != nil {
+				return err
+			}
+		case ast.AlterTableDropPrimaryKey:
+			if tb.PrimaryKey != nil {
+				removeIndex(tb, tb.PrimaryKey)
+			}
+		case ast.AlterTableDropIndex:
+			if err = dropIndex(tb, spec.Name, spec.IfExists); err != nil {
+				return err
+			}
+		case ast.AlterTableDropForeignKey:
+			fk, ok := tb.ForeignKey(spec.Name)
+			if !ok {
+				if spec.IfExists {
+					continue
+				}
+				return fmt.Errorf("foreign key '%s' doesn't exist in table '%s'", spec.Name, tb.Name)
+			}
+			removeForeignKey(tb, fk)
+		case ast.AlterTableRenameIndex:
+			index, ok := findIndex(tb, spec.FromKey.O)
+			if !ok {
+				return fmt.Errorf("key '%s' doesn't exist in table '%s'", spec.FromKey.O, tb.Name)
+			}
+			index.Name = spec.ToKey.O
+		case ast.AlterTableRenameTable:
+			if err = ts.renameTable(tb.Name, spec.NewTable.Name.L); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (ts *tidbTables) renameTable(oldName, newName string) error {
+	tb, err := ts.mustFind(oldName)
+	if err != nil {
+		return err
+	}
+	if _, ok := ts.find(newName); ok {
+		return fmt.Errorf("table '%s' already exists", newName)
+	}
+	tb.Name = newName
+	return nil
+}
+
+func (ts *tidbTables) addConstraint(tb *schema.Table, constraint *ast.Constraint) error {
+	if constraint.Tp == ast.ConstraintForeignKey {
+		return ts.addForeignKey(tb, constraint)
+	}
+	if constraint.Tp == ast.ConstraintPrimaryKey && tb.PrimaryKey != nil {
+		return fmt.Errorf("multiple primary key defined in table '%s'", tb.Name)
+	}
+	index, err := parseCreateTableStmtIndex(tb, constraint, tb.Columns)
+	if err != nil {
+		return err
+	}
+	if index == nil { // not an index, like check.
+		return nil
+	}
+	if index.Name == "" {
+		index.Name = uniqueIndexName(tb, index)
+	}
+	if _, ok := findIndex(tb, index.Name); ok && index != tb.PrimaryKey {
+		return fmt.Errorf("duplicate key name '%s' in table '%s'", index.Name, tb.Name)
+	}
+	tb.AddIndexes(index)
+	return nil
+}
+
+// uniqueIndexName returns the name of the unnamed index like mysql, the first column name,
+// or `functional_index` for the functional key part, with suffix `_2`, `_3`... if duplicate.
+func uniqueIndexName(tb *schema.Table, index *schema.Index) string {
+	name := "functional_index"
+	if c := index.Parts[0].C; c != nil {
+		name = c.Name
+	}
+	for i, v := 2, name; ; i++ {
+		if _, ok := findIndex(tb, v); !ok {
+			return v
+		}
+		v = name + "_" + strconv.Itoa(i)
+	}
+}
+
+// addForeignKey add foreign key to table, if the reference table not exist yet,
+// it references a stub table with the reference columns only, which resolved after all statements applied.
+func (ts *tidbTables) addForeignKey(tb *schema.Table, constraint *ast.Constraint) error {
+	columns := make([]*schema.Column, 0, len(constraint.Keys))
+	for _, key := range constraint.Keys {
+		col, ok := tb.Column(key.Column.Name.L)
+		if !ok {
+			return fmt.Errorf("foreign key('%s') column '%s' doesn't exist in table '%s'", constraint.Name, key.Column.Name.L, tb.Name)
+		}
+		columns = append(columns, col)
+	}
+	refer := constraint.Refer
+	refTable, exist := ts.find(refer.Table.Name.L)
+	if !exist {
+		refTable = ts.stub(refer.Table.Name.L)
+	}
+	refColumns := make([]*schema.Column, 0, len(refer.IndexPartSpecifications))
+	for _, key := range refer.IndexPartSpecifications {
+		col, ok := refTable.Column(key.Column.Name.L)
+		if !ok {
+			if exist {
+				return fmt.Errorf("foreign key('%s') reference column '%s' doesn't exist in table '%s'", constraint.Name, key.Column.Name.L, refTable.Name)
+			}
+			col = schema.NewColumn(key.Column.Name.L)
+			refTable.AddColumns(col)
+		}
+		refColumns = append(refColumns, col)
+	}
+	fk := schema.NewForeignKey(constraint.Name).
+		AddColumns(columns...).
+		SetRefTable(refTable).
+		AddRefColumns(refColumns...)
+	if refer.OnUpdate != nil {
+		fk.SetOnUpdate(schema.ReferenceOption(refer.OnUpdate.ReferOpt.String()))
+	}
+	if refer.OnDelete != nil {
+		fk.SetOnDelete(schema.ReferenceOption(refer.OnDelete.ReferOpt.String()))
+	}
+	tb.AddForeignKeys(fk)
+	return nil
+}
+
+// stub returns the stub reference table of the name, created if not exist.
+func (ts *tidbTables) stub(name string) *schema.Table {
+	i := slices.IndexFunc(ts.stubs, func(tb *schema.Table) bool { return tb.Name == name })
+	if i != -1 {
+		return ts.stubs[i]
+	}
+	tb := schema.NewTable(name)
+	ts.stubs = append(ts.stubs, tb)
+	return tb
+}
+
+// resolveForeignKeys bind the foreign keys which reference the stub tables to the tables created later,
+// same as file+mysql, the reference table not in the statements keep the stub.
+func (ts *tidbTables) resolveForeignKeys() error {
+	for _, tb := range ts.tables {
+		for _, fk := range tb.ForeignKeys {
+			if !slices.Contains(ts.stubs, fk.RefTable) {
+				continue
+			}
+			refTable, ok := ts.find(fk.RefTable.Name)
+			if !ok {
+				continue
+			}
+			for i, col := range fk.RefColumns {
+				refCol, ok := refTable.Column(col.Name)
+				if !ok {
+					return fmt.Errorf("foreign key('%s') reference column '%s' doesn't exist in table '%s'", fk.Symbol, col.Name, refTable.Name)
+				}
+				fk.RefColumns[i] = refCol
+			}
+			fk.RefTable = refTable
+		}
+	}
+	return nil
+}
+
+// moveColumn move the column to the position, FIRST or AFTER column.
+func moveColumn(tb *schema.Table, col *schema.Column, pos *ast.ColumnPosition) error {
+	if pos == nil || pos.Tp == ast.ColumnPositionNone {
+		return nil
+	}
+	columns := slices.DeleteFunc(tb.Columns, func(c *schema.Column) bool { return c == col })
+	idx := 0
+	if pos.Tp == ast.ColumnPositionAfter {
+		after := slices.IndexFunc(columns, func(c *schema.Column) bool { return c.Name == pos.RelativeColumn.Name.L })
+		if after == -1 {
+			return fmt.Errorf("column '%s' doesn't exist in table '%s'", pos.RelativeColumn.Name.L, tb.Name)
+		}
+		idx = after + 1
+	}
+	tb.Columns = slices.Insert(columns, idx, col)
+	return nil
+}
+
+// dropColumn drop the column, and remove it from the indexes and foreign keys.
+func dropColumn(tb *schema.Table, col *schema.Column) {
+	tb.Columns = slices.DeleteFunc(tb.Columns, func(c *schema.Column) bool { return c == col })
+	for _, index := range slices.Clone(col.Indexes) {
+		index.Parts = slices.DeleteFunc(index.Parts, func(p *schema.IndexPart) bool { return p.C == col })
+		if len(index.Parts) == 0 {
+			removeIndex(tb, index)
+			continue
+		}
+		for i, p := range index.Parts {
+			p.SeqNo = i
+		}
+	}
+	for _, fk := range slices.Clone(col.ForeignKeys) {
+		removeForeignKey(tb, fk)
+	}
+}
+
+func findIndex(tb *schema.Table, name string) (*schema.Index, bool) {
+	if pk := tb.PrimaryKey; pk != nil && strings.EqualFold(pk.Name, name) {
+		return pk, true
+	}
+	for _, index := range tb.Indexes {
+		if strings.EqualFold(index.Name, name) {
+			return index, true
+		}
+	}
+	return nil, false
+}
+
+func dropIndex(tb *schema.Table, name string, ifExists bool) error {
+	index, ok := findIndex(tb, name)
+	if !ok {
+		if ifExists {
+			return nil
+		}
+		return fmt.Errorf("key '%s' doesn't exist in table '%s'", name, tb.Name)
+	}
+	removeIndex(tb, index)
+	return nil
+}
+
+// removeIndex remove the index from table and the columns.
+func removeIndex(tb *schema.Table, index *schema.Index) {
+	if tb.PrimaryKey == index {
+		tb.PrimaryKey = nil
+	}
+	tb.Indexes = slices.DeleteFunc(tb.Indexes, func(idx *schema.Index) bool { return idx == index })
+	for _, col := range tb.Columns {
+		col.Indexes = slices.DeleteFunc(col.Indexes, func(idx *schema.Index) bool { return idx == index })
+	}
+}
+
+// removeForeignKey remove the foreign key from table and the columns.
+func removeForeignKey(tb *schema.Table, fk *schema.ForeignKey) {
+	tb.ForeignKeys = slices.DeleteFunc(tb.ForeignKeys, func(v *schema.ForeignKey) bool { return v == fk })
+	for _, col := range tb.Columns {
+		col.ForeignKeys = slices.DeleteFunc(col.ForeignKeys, func(v *schema.ForeignKey) bool { return v == fk })
+	}
+}
@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"regexp"
+
+	"ariga.io/atlas/sql/schema"
+	"github.com/lib/pq"
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/internal/insql"
+)
+
+var (
+	uuidType         = ens.NewGoType(ens.TypeUUID, "")
+	boolArrayType    = ens.NewGoType(ens.TypeOther, pq.BoolArray{})
+	int32ArrayType   = ens.NewGoType(ens.TypeOther, pq.Int32Array{})
+	int64ArrayType   = ens.NewGoType(ens.TypeOther, pq.Int64Array{})
+	float32ArrayType = ens.NewGoType(ens.TypeOther, pq.Float32Array{})
+	float64ArrayType = ens.NewGoType(ens.TypeOther, pq.Float64Array{})
+	byteaArrayType   = ens.NewGoType(ens.TypeOther, pq.ByteaArray{})
+	stringArrayType  = ens.NewGoType(ens.TypeOther, pq.StringArray{})
+)
+
+func UUIDType() ens.GoType         { return uuidType }
+func BoolArrayType() ens.GoType    { return boolArrayType }
+func Int32ArrayType() ens.GoType   { return int32ArrayType }
+func Int64ArrayType() ens.GoType   { return int64ArrayType }
+func Float32ArrayType() ens.GoType { return float32ArrayType }
+func Float64ArrayType() ens.GoType { return float64ArrayType }
+func ByteaArrayType() ens.GoType   { return byteaArrayType }
+func StringArrayType() ens.GoType  { return stringArrayType }
+
+// 匹配 postgres.FormatType 格式化后的类型
+var typeDictMatchList = []struct {
+	Key     string
+	NewType func() ens.GoType
+}{
+	{`^(boolean|bool)\[\]$`, BoolArrayType},                                       // pq.BoolArray
+	{`^(smallint|int2|integer|int|int4|smallserial|serial)\[\]$`, Int32ArrayType}, // pq.Int32Array
+	{`^(bigint|int8|bigserial)\[\]$`, Int64ArrayType},                             // pq.Int64Array
+	{`^(real|float4)\[\]$`, Float32ArrayType},                                     // pq.Float32Array
+	{`^(double precision|float8)\[\]$`, Float64ArrayType},                         // pq.Float64Array
+	{`^(bytea)\[\]$`, ByteaArrayType},                                             // pq.ByteaArray
+	{`\[\]$`, StringArrayType},                                                    // pq.StringArray
+	{`^(boolean|bool)$`, ens.BoolType},                                            // bool
+	{`^(smallint|int2|smallserial|serial2)$`, ens.Int16Type},                      // int16
+	{`^(integer|int|int4|serial|serial4)$`, ens.Int32Type},                        // int32
+	{`^(bigint|int8|bigserial|serial8)$`, ens.Int64Type},                          // int64
+	{`^(real|float4)$`, ens.Float32Type},                                          // float32
+	{`^(double precision|float8|float)\b`, ens.Float64Type},                       // float64
+	{`^(numeric|decimal)\b`, ens.DecimalType},                                     // string
+	{`^(money)$`, ens.DecimalType},                                                // string
+	{`^(character varying|varchar|character|char|bpchar)\b`, ens.StringType},      // string
+	{`^(text|citext|name)$`, ens.StringType},                                      // string
+	{`^(timestamp|time|timetz|timestamptz)\b`, ens.TimeType},                      // time.Time
+	{`^(date)$`, ens.TimeType},                                                    // time.Time
+	{`^(interval)\b`, ens.StringType},                                             // string
+	{`^(json|jsonb)$`, ens.JSONRawMessageType},                                    // json.RawMessage
+	{`^(uuid)$`, UUIDType},                                                        // string
+	{`^(bytea)$`, ens.BytesType},                                                  // []byte
+	{`^(bit|bit varying)\b`, ens.StringType},                                      // string
+	{`^(inet|cidr|macaddr|macaddr8)$`, ens.StringType},                            // string
+	{`^(xml|tsvector|tsquery)$`, ens.StringType},                                  // string
+}
+
+var typeDictMatchRegexp = func() []*regexp.Regexp {
+	res := make([]*regexp.Regexp, 0, len(typeDictMatchList))
+	for _, v := range typeDictMatchList {
+		res = append(res, regexp.MustCompile(v.Key))
+	}
+	return res
+}()
+
+func intoGoType(colType *schema.ColumnType) ens.GoType {
+	if _, ok := colType.Type.(*schema.EnumType); ok {
+		return ens.EnumType()
+	}
+	columnType := formatType(colType)
+	for i, re := range typeDictMatchRegexp {
+		if re.MatchString(columnType) {
+			return typeDictMatchList[i].NewType()
+		}
+	}
+	// postgres can always represent a value in text format.
+	return ens.StringType()
+}
+
+// intoFieldType returns the go type of the column, whether the field need pointer and the type is customized,
+// the comment annotation `[@gotype: xxx]`, `[@jsontype: xxx]` take precedence over the user type mappings, then the builtin.
+func intoFieldType(col *schema.Column) (t ens.GoType, pointer, custom bool) {
+	var ok bool
+
+	t, pointer, ok = ens.MatchTypeMapping(formatType(col.Type), col.Type.Null)
+	if !ok {
+		t, pointer = intoGoType(col.Type), col.Type.Null
+	}
+	if v, p, ok := ens.AnnotatedGoType(insql.MustComment(col.Attrs), t.Type, col.Type.Null); ok {
+		return v, p, true
+	}
+	return t, pointer, false
+}
+
+type TableDef struct {
+	tb *schema.Table
+}
+
+func NewTableDef(tb *schema.Table) ens.TableDef {
+	return &TableDef{tb: tb}
+}
+
+func (d *TableDef) Table() *schema.Table { return d.tb }
+
+func (d *TableDef) Dialect() string { return ens.DialectPostgres }
+
+func (d *TableDef) PrimaryKey() ens.IndexDef {
+	if d.tb.PrimaryKey != nil {
+		return NewIndexDef(d.tb.PrimaryKey)
+	}
+	return nil
+}
+
+func (d *TableDef) Definition() string {
+	return intoTableSql(d.tb)
+}
+
+type ColumnDef struct {
+	col *schema.Column
+}
+
+func NewColumnDef(col *schema.Column) ens.ColumnDef {
+	return &ColumnDef{col: col}
+}
+
+func (d *ColumnDef) Column() *schema.Column { return d.col }
+
+func (d *ColumnDef) Definition() string { return intoColumnSql(d.col) }
+
+func (d *ColumnDef) GormTag(tb *schema.Table) string { return intoGormTag(tb, d.col) }
+
+type IndexDef struct {
+	index *schema.Index
+}
+
+func NewIndexDef(index *schema.Index) ens.IndexDef { return &IndexDef{index: index} }
+
+func (d *IndexDef) Index() *schema.Index { return d.index }
+
+func (d *IndexDef) Definition() string { return intoIndexSql(d.index) }
+
+type ForeignKeyDef struct {
+	fk *schema.ForeignKey
+}
+
+func NewForeignKey(fk *schema.ForeignKey) ens.ForeignKeyDef {
+	return &ForeignKeyDef{fk: fk}
+}
+
+func (d *ForeignKeyDef) ForeignKey() *schema.ForeignKey { return d.fk }
+
+func (d *ForeignKeyDef) Definition() string { return intoForeignKeySql(d.fk) }
@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/internal/insql"
+	"github.com/things-go/ens/utils"
+)
+
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func quoteColumnNames(columns []*schema.Column) string {
+	names := make([]string, 0, len(columns))
+	for _, col := range columns {
+		names = append(names, quote(col.Name))
+	}
+	return strings.Join(names, ", ")
+}
+
+func intoColumnSql(col *schema.Column) string {
+	b := &strings.Builder{}
+	b.Grow(64)
+	b.WriteString(formatType(col.Type))
+	if !col.Type.Null {
+		b.WriteString(" NOT NULL")
+	}
+	if generation, ok := identity(col.Attrs); ok {
+		fmt.Fprintf(b, " GENERATED %s AS IDENTITY", cmp.Or(generation, "BY DEFAULT"))
+	} else if !isSerial(col.Type) {
+		switch x := schema.UnderlyingExpr(col.Default).(type) {
+		case *schema.Literal:
+			fmt.Fprintf(b, " DEFAULT %s", x.V)
+		case *schema.RawExpr:
+			fmt.Fprintf(b, " DEFAULT %s", x.X)
+		default:
+			// do nothing
+		}
+	}
+	return b.String()
+}
+
+func intoIndexSql(index *schema.Index) string {
+	parts := make([]string, 0, len(index.Parts))
+	for _, p := range index.Parts {
+		switch {
+		case p.C != nil:
+			parts = append(parts, quote(p.C.Name))
+		case p.X != nil:
+			if x, ok := p.X.(*schema.RawExpr); ok {
+				parts = append(parts, "("+x.X+")")
+			}
+		}
+	}
+	fieldList := strings.Join(parts, ", ")
+	if insql.IndexEqual(index.Table.PrimaryKey, index) {
+		return fmt.Sprintf("PRIMARY KEY (%s)", fieldList)
+	}
+	unique := ""
+	if index.Unique {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s USING %s (%s)", unique, quote(index.Name), quote(index.Table.Name), strings.ToLower(findIndexType(index.Attrs)), fieldList)
+}
+
+func intoForeignKeySql(fk *schema.ForeignKey) string {
+	b := &strings.Builder{}
+	b.Grow(128)
+	fmt.Fprintf(b,
+		"CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		quote(fk.Symbol), quoteColumnNames(fk.Columns), quote(fk.RefTable.Name), quoteColumnNames(fk.RefColumns),
+	)
+	if fk.OnUpdate != "" {
+		fmt.Fprintf(b, " ON UPDATE %s", fk.OnUpdate)
+	}
+	if fk.OnDelete != "" {
+		fmt.Fprintf(b, " ON DELETE %s", fk.OnDelete)
+	}
+	return b.String()
+}
+
+func intoTableSql(tb *schema.Table) string {
+	b := &strings.Builder{}
+	b.Grow(256)
+	fmt.Fprintf(b, "CREATE TABLE %s (\n", quote(tb.Name))
+
+	remain := len(tb.Columns) + len(tb.ForeignKeys)
+	if tb.PrimaryKey != nil {
+		remain++
+	}
+	suffixOrEmpty := func(r int) string {
+		if r == 0 {
+			return ""
+		}
+		return ","
+	}
+	//* columns
+	for _, col := range tb.Columns {
+		remain--
+		suffix := suffixOrEmpty(remain)
+		fmt.Fprintf(b, "  %s %s%s\n", quote(col.Name), intoColumnSql(col), suffix)
+	}
+	//* pk
+	if tb.PrimaryKey != nil {
+		remain--
+		suffix := suffixOrEmpty(remain)
+		fmt.Fprintf(b, "  %s%s\n", intoIndexSql(tb.PrimaryKey), suffix)
+	}
+	//* foreignKeys
+	for _, fk := range tb.ForeignKeys {
+		remain--
+		suffix := suffixOrEmpty(remain)
+		fmt.Fprintf(b, "  %s%s\n", intoForeignKeySql(fk), suffix)
+	}
+	b.WriteString(")")
+	//* indexes, postgres not support inline index.
+	for _, index := range tb.Indexes {
+		if insql.IndexEqual(tb.PrimaryKey, index) { // ignore primary key, maybe include
+			continue
+		}
+		fmt.Fprintf(b, ";\n%s", intoIndexSql(index))
+	}
+	//* comments
+	if comment, ok := insql.Comment(tb.Attrs); ok && comment != "" {
+		fmt.Fprintf(b, ";\nCOMMENT ON TABLE %s IS %s", quote(tb.Name), quoteString(comment))
+	}
+	for _, col := range tb.Columns {
+		if comment, ok := insql.Comment(col.Attrs); ok && comment != "" {
+			fmt.Fprintf(b, ";\nCOMMENT ON COLUMN %s.%s IS %s", quote(tb.Name), quote(col.Name), quoteString(comment))
+		}
+	}
+	return b.String()
+}
+
+// column, type, not null, authIncrement, default, [primaryKey|index], comment
+func intoGormTag(tb *schema.Table, col *schema.Column) string {
+	pkPriority, isPk := 0, false
+	if pk := tb.PrimaryKey; pk != nil {
+		pkPriority, isPk = insql.FindIndexPartSeq(pk.Parts, col)
+	}
+	autoIncrement := autoIncrement(col)
+
+	b := &strings.Builder{}
+	b.Grow(64)
+	fmt.Fprintf(b, `gorm:"column:%s`, col.Name)
+	if !isPk || !autoIncrement {
+		fmt.Fprintf(b, ";type:%s", formatType(col.Type))
+	}
+	if !col.Type.Null {
+		fmt.Fprintf(b, ";not null")
+	}
+
+	if autoIncrement {
+		fmt.Fprintf(b, ";autoIncrement:true")
+	} else {
+		dv := ""
+		switch x := schema.UnderlyingExpr(col.Default).(type) {
+		case *schema.Literal:
+			dv = x.V
+		case *schema.RawExpr:
+			dv = x.X
+		case nil:
+			if col.Type.Null {
+				dv = "null"
+			}
+		default:
+			// do nothing
+		}
+		if dv != "" {
+			fmt.Fprintf(b, ";default:%s", dv)
+		}
+	}
+
+	//* pk + indexes
+	if isPk && tb.PrimaryKey != nil {
+		fmt.Fprintf(b, ";primaryKey")
+		if len(tb.PrimaryKey.Parts) > 1 {
+			fmt.Fprintf(b, ",priority:%d", pkPriority)
+		}
+	}
+	for _, val := range col.Indexes {
+		if insql.IndexEqual(tb.PrimaryKey, val) { // ignore primary key, may be include
+			continue
+		}
+		if val.Unique {
+			fmt.Fprintf(b, ";uniqueIndex:%s", val.Name)
+		} else {
+			fmt.Fprintf(b, ";index:%s", val.Name)
+		}
+		if len(val.Parts) > 1 {
+			priority, ok := insql.FindIndexPartSeq(val.Parts, col)
+			if ok {
+				fmt.Fprintf(b, ",priority:%d", priority)
+			}
+		}
+	}
+	if comment, ok := insql.Comment(col.Attrs); ok && comment != "" {
+		fmt.Fprintf(b, ";comment:%s", utils.TrimFieldComment(comment))
+	}
+	b.WriteString(`"`)
+	return b.String()
+}
+
+func intoSchema(tb *schema.Table) *ens.EntityDescriptor {
+	// * columns
+	fielders := make([]*ens.FieldDescriptor, 0, len(tb.Columns))
+	for _, col := range tb.Columns {
+		goType, goPointer, customType := intoFieldType(col)
+		fielders = append(fielders, &ens.FieldDescriptor{
+			ColumnName: col.Name,
+			Comment:    insql.MustComment(col.Attrs),
+			Nullable:   col.Type.Null,
+			Column:     NewColumnDef(col),
+			Type:       goType,
+			GoName:     utils.PascalCase(col.Name),
+			GoPointer:  goPointer,
+			CustomType: customType,
+			Tags:       []string{intoGormTag(tb, col)},
+		})
+	}
+	// * indexes
+	indexers := make([]*ens.IndexDescriptor, 0, len(tb.Indexes))
+	for _, index := range tb.Indexes {
+		indexers = append(indexers, &ens.IndexDescriptor{
+			Name:   index.Name,
+			Fields: insql.IndexPartColumnNames(index.Parts),
+			Index:  NewIndexDef(index),
+		})
+	}
+	//* foreignKeys
+	fks := make([]*ens.ForeignKeyDescriptor, 0, len(tb.ForeignKeys))
+	for _, fk := range tb.ForeignKeys {
+		fks = append(fks, &ens.ForeignKeyDescriptor{
+			Symbol:     fk.Symbol,
+			Table:      fk.Table.Name,
+			Columns:    insql.ColumnNames(fk.Columns),
+			RefTable:   fk.RefTable.Name,
+			RefColumns: insql.ColumnNames(fk.RefColumns),
+			OnUpdate:   fk.OnUpdate,
+			OnDelete:   fk.OnDelete,
+			ForeignKey: NewForeignKey(fk),
+		})
+	}
+
+	// * table
+	return &ens.EntityDescriptor{
+		Name:        tb.Name,
+		Comment:     insql.MustComment(tb.Attrs),
+		Table:       NewTableDef(tb),
+		Fields:      fielders,
+		Indexes:     indexers,
+		ForeignKeys: fks,
+	}
+}
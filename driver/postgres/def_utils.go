@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/things-go/ens/internal/insql"
+)
+
+// formatType returns the column type in the database form,
+// fallback to the raw type if it can not be formatted.
+func formatType(colType *schema.ColumnType) string {
+	if colType.Type != nil {
+		if v, err := postgres.FormatType(colType.Type); err == nil {
+			return v
+		}
+	}
+	return colType.Raw
+}
+
+func isSerial(colType *schema.ColumnType) bool {
+	_, ok := colType.Type.(*postgres.SerialType)
+	return ok
+}
+
+func identity(attrs []schema.Attr) (string, bool) {
+	var val postgres.Identity
+	ok := insql.Has(attrs, &val)
+	return val.Generation, ok
+}
+
+// autoIncrement reports whether the column is a serial or an identity column.
+func autoIncrement(col *schema.Column) bool {
+	_, ok := identity(col.Attrs)
+	return ok || isSerial(col.Type)
+}
+
+func findIndexType(attrs []schema.Attr) string {
+	var t postgres.IndexType
+	if insql.Has(attrs, &t) && t.T != "" {
+		return t.T
+	} else {
+		return postgres.IndexTypeBTree
+	}
+}
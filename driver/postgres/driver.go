@@ -0,0 +1,9 @@
+package postgres
+
+import (
+	"github.com/things-go/ens/driver"
+)
+
+func init() {
+	driver.RegisterDriver(driver.Postgres, &Postgres{})
+}
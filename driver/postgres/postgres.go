@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlclient"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+
+	_ "ariga.io/atlas/sql/postgres"
+	_ "github.com/lib/pq"
+)
+
+var _ driver.Driver = (*Postgres)(nil)
+
+type Postgres struct{}
+
+func (pg *Postgres) InspectSchema(ctx context.Context, arg *driver.InspectOption) (*ens.Schema, error) {
+	schemaes, err := pg.inspectSchema(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]*ens.EntityDescriptor, 0, len(schemaes.Tables))
+	for _, tb := range schemaes.Tables {
+		entities = append(entities, intoSchema(tb))
+	}
+	return &ens.Schema{
+		Name:     schemaes.Name,
+		Entities: entities,
+	}, nil
+}
+
+func (pg *Postgres) inspectSchema(ctx context.Context, arg *driver.InspectOption) (*schema.Schema, error) {
+	client, err := sqlclient.Open(ctx, arg.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return client.InspectSchema(ctx, "", &arg.InspectOptions)
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"strings"
+	"testing"
+
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/internal/insql"
+)
+
+func testRealm() *schema.Realm {
+	users := schema.NewTable("users").
+		AddColumns(
+			schema.NewColumn("id").SetType(&postgres.SerialType{T: postgres.TypeBigSerial}),
+			schema.NewColumn("name").SetType(&schema.StringType{T: postgres.TypeCharVar, Size: 64}).SetDefault(&schema.Literal{V: "''"}).SetComment("用户名"),
+			schema.NewNullColumn("nickname").SetType(&schema.StringType{T: postgres.TypeText}),
+			schema.NewColumn("age").SetType(&schema.IntegerType{T: postgres.TypeInt4}),
+			schema.NewColumn("balance").SetType(&schema.DecimalType{T: postgres.TypeNumeric, Precision: 10, Scale: 2}),
+			schema.NewColumn("uid").SetType(&schema.UUIDType{T: postgres.TypeUUID}),
+			schema.NewNullColumn("profile").SetType(&schema.JSONType{T: postgres.TypeJSONB}),
+			schema.NewColumn("tags").SetType(&postgres.ArrayType{T: "text[]", Type: &schema.StringType{T: postgres.TypeText}}),
+			schema.NewColumn("scores").SetType(&postgres.ArrayType{T: "integer[]", Type: &schema.IntegerType{T: postgres.TypeInteger}}),
+			schema.NewColumn("created_at").SetType(&schema.TimeType{T: postgres.TypeTimestampWTZ, Precision: insql.P(6)}).SetDefault(&schema.RawExpr{X: "now()"}),
+		).
+		SetComment("用户表")
+	users.SetPrimaryKey(schema.NewPrimaryKey(users.Columns[0]))
+	users.AddIndexes(schema.NewUniqueIndex("uk_users_uid").AddColumns(users.Columns[5]))
+
+	posts := schema.NewTable("posts").
+		AddColumns(
+			schema.NewColumn("id").SetType(&schema.IntegerType{T: postgres.TypeInteger}).AddAttrs(&postgres.Identity{Generation: "BY DEFAULT"}),
+			schema.NewColumn("user_id").SetType(&schema.IntegerType{T: postgres.TypeBigInt}),
+			schema.NewColumn("title").SetType(&schema.StringType{T: postgres.TypeVarChar, Size: 255}),
+		)
+	posts.SetPrimaryKey(schema.NewPrimaryKey(posts.Columns[0]))
+	posts.AddForeignKeys(
+		schema.NewForeignKey("posts_user_id_fkey").
+			AddColumns(posts.Columns[1]).
+			SetRefTable(users).
+			AddRefColumns(users.Columns[0]).
+			SetOnDelete(schema.Cascade),
+	)
+	return schema.NewRealm(schema.New("public").AddTables(users, posts))
+}
+
+func Test_intoSchema(t *testing.T) {
+	realm := testRealm()
+	sc, ok := realm.Schema("public")
+	if !ok {
+		t.Fatal("schema public not found")
+	}
+	users, _ := sc.Table("users")
+	entity := intoSchema(users)
+
+	wantTypes := map[string]ens.GoType{
+		"id":         ens.Int64Type(),
+		"name":       ens.StringType(),
+		"nickname":   ens.StringType(),
+		"age":        ens.Int32Type(),
+		"balance":    ens.DecimalType(),
+		"uid":        UUIDType(),
+		"profile":    ens.JSONRawMessageType(),
+		"tags":       StringArrayType(),
+		"scores":     Int32ArrayType(),
+		"created_at": ens.TimeType(),
+	}
+	if len(entity.Fields) != len(wantTypes) {
+		t.Fatalf("fields count = %d, want %d", len(entity.Fields), len(wantTypes))
+	}
+	for _, field := range entity.Fields {
+		want := wantTypes[field.ColumnName]
+		if field.Type != want {
+			t.Errorf("column %s type = %+v, want %+v", field.ColumnName, field.Type, want)
+		}
+	}
+	if got, want := entity.Fields[0].Tags[0], `gorm:"column:id;not null;autoIncrement:true;primaryKey"`; got != want {
+		t.Errorf("gorm tag = %v, want %v", got, want)
+	}
+	if got, want := entity.Fields[1].Tags[0], `gorm:"column:name;type:character varying(64);not null;default:'';comment:用户名"`; got != want {
+		t.Errorf("gorm tag = %v, want %v", got, want)
+	}
+	if got, want := entity.Fields[5].Tags[0], `gorm:"column:uid;type:uuid;not null;uniqueIndex:uk_users_uid"`; got != want {
+		t.Errorf("gorm tag = %v, want %v", got, want)
+	}
+
+	definition := entity.Table.Definition()
+	for _, want := range []string{
+		`CREATE TABLE "users" (`,
+		`"id" bigserial NOT NULL,`,
+		`"created_at" timestamptz NOT NULL DEFAULT now(),`,
+		`PRIMARY KEY ("id")`,
+		`CREATE UNIQUE INDEX "uk_users_uid" ON "users" USING btree ("uid")`,
+		`COMMENT ON TABLE "users" IS '用户表'`,
+		`COMMENT ON COLUMN "users"."name" IS '用户名'`,
+	} {
+		if !strings.Contains(definition, want) {
+			t.Errorf("definition missing %q, got:\n%s", want, definition)
+		}
+	}
+}
+
+func Test_intoSchema_ForeignKey(t *testing.T) {
+	realm := testRealm()
+	sc, _ := realm.Schema("public")
+	posts, _ := sc.Table("posts")
+	entity := intoSchema(posts)
+
+	if got, want := entity.Fields[0].Tags[0], `gorm:"column:id;not null;autoIncrement:true;primaryKey"`; got != want {
+		t.Errorf("gorm tag = %v, want %v", got, want)
+	}
+	if len(entity.ForeignKeys) != 1 {
+		t.Fatalf("foreign keys count = %d, want 1", len(entity.ForeignKeys))
+	}
+	fk := entity.ForeignKeys[0]
+	if fk.RefTable != "users" || fk.Columns[0] != "user_id" || fk.RefColumns[0] != "id" {
+		t.Errorf("unexpected foreign key: %+v", fk)
+	}
+	definition := entity.Table.Definition()
+	for _, want := range []string{
+		`"id" integer NOT NULL GENERATED BY DEFAULT AS IDENTITY,`,
+		`CONSTRAINT "posts_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE`,
+	} {
+		if !strings.Contains(definition, want) {
+			t.Errorf("definition missing %q, got:\n%s", want, definition)
+		}
+	}
+}
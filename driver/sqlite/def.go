@@ -0,0 +1,161 @@
+package sqlite
+
+import (
+	"strings"
+
+	"ariga.io/atlas/sql/schema"
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/internal/insql"
+)
+
+var uuidType = ens.NewGoType(ens.TypeUUID, "")
+
+func UUIDType() ens.GoType { return uuidType }
+
+// intoGoType convert the declared column type to go type.
+// It follows the sqlite type affinity rules, see: https://www.sqlite.org/datatype3.html#determination_of_column_affinity
+//  1. contains "INT", INTEGER affinity.
+//  2. contains "CHAR", "CLOB" or "TEXT", TEXT affinity.
+//  3. contains "BLOB" or no type, BLOB affinity.
+//  4. contains "REAL", "FLOA" or "DOUB", REAL affinity.
+//  5. otherwise, NUMERIC affinity.
+//
+// then refine it with common declared type names, like tinyint, boolean, datetime, decimal, json.
+func intoGoType(columnType string) ens.GoType {
+	typ := strings.ToUpper(strings.TrimSpace(columnType))
+	name := typ
+	if i := strings.IndexAny(name, "( "); i != -1 {
+		name = name[:i]
+	}
+	unsigned := strings.Contains(typ, "UNSIGNED")
+	switch {
+	case strings.Contains(typ, "INT"): // INTEGER affinity
+		switch name {
+		case "TINYINT", "INT1":
+			if strings.HasPrefix(typ, "TINYINT(1)") {
+				return ens.BoolType()
+			}
+			if unsigned {
+				return ens.Uint8Type()
+			}
+			return ens.Int8Type()
+		case "SMALLINT", "INT2":
+			if unsigned {
+				return ens.Uint16Type()
+			}
+			return ens.Int16Type()
+		case "MEDIUMINT", "INT", "INT4":
+			if unsigned {
+				return ens.Uint32Type()
+			}
+			return ens.Int32Type()
+		default: // INTEGER, BIGINT, INT8 ...
+			if unsigned {
+				return ens.Uint64Type()
+			}
+			return ens.Int64Type()
+		}
+	case strings.Contains(typ, "CHAR"),
+		strings.Contains(typ, "CLOB"),
+		strings.Contains(typ, "TEXT"): // TEXT affinity
+		return ens.StringType()
+	case typ == "", strings.Contains(typ, "BLOB"): // BLOB affinity
+		return ens.BytesType()
+	case strings.Contains(typ, "REAL"),
+		strings.Contains(typ, "FLOA"),
+		strings.Contains(typ, "DOUB"): // REAL affinity
+		if name == "FLOAT" {
+			return ens.Float32Type()
+		}
+		return ens.Float64Type()
+	default: // NUMERIC affinity
+		switch name {
+		case "BOOL", "BOOLEAN":
+			return ens.BoolType()
+		case "DATE", "DATETIME", "TIMESTAMP", "TIME":
+			return ens.TimeType()
+		case "JSON", "JSONB":
+			return ens.JSONRawMessageType()
+		case "UUID":
+			return UUIDType()
+		case "BINARY", "VARBINARY":
+			return ens.BytesType()
+		default: // NUMERIC, DECIMAL ...
+			return ens.DecimalType()
+		}
+	}
+}
+
+// intoFieldType returns the go type of the column, whether the field need pointer and the type is customized,
+// the comment annotation `[@gotype: xxx]`, `[@jsontype: xxx]` take precedence over the user type mappings, then the builtin.
+func intoFieldType(col *schema.Column) (t ens.GoType, pointer, custom bool) {
+	var ok bool
+
+	t, pointer, ok = ens.MatchTypeMapping(col.Type.Raw, col.Type.Null)
+	if !ok {
+		t, pointer = intoGoType(col.Type.Raw), col.Type.Null
+	}
+	if v, p, ok := ens.AnnotatedGoType(insql.MustComment(col.Attrs), t.Type, col.Type.Null); ok {
+		return v, p, true
+	}
+	return t, pointer, false
+}
+
+type TableDef struct {
+	tb *schema.Table
+}
+
+func NewTableDef(tb *schema.Table) ens.TableDef {
+	return &TableDef{tb: tb}
+}
+
+func (d *TableDef) Table() *schema.Table { return d.tb }
+
+func (d *TableDef) Dialect() string { return ens.DialectSQLite }
+
+func (d *TableDef) PrimaryKey() ens.IndexDef {
+	if d.tb.PrimaryKey != nil {
+		return NewIndexDef(d.tb.PrimaryKey)
+	}
+	return nil
+}
+
+func (d *TableDef) Definition() string {
+	return intoTableSql(d.tb)
+}
+
+type ColumnDef struct {
+	col *schema.Column
+}
+
+func NewColumnDef(col *schema.Column) ens.ColumnDef {
+	return &ColumnDef{col: col}
+}
+
+func (d *ColumnDef) Column() *schema.Column { return d.col }
+
+func (d *ColumnDef) Definition() string { return intoColumnSql(d.col) }
+
+func (d *ColumnDef) GormTag(tb *schema.Table) string { return intoGormTag(tb, d.col) }
+
+type IndexDef struct {
+	index *schema.Index
+}
+
+func NewIndexDef(index *schema.Index) ens.IndexDef { return &IndexDef{index: index} }
+
+func (d *IndexDef) Index() *schema.Index { return d.index }
+
+func (d *IndexDef) Definition() string { return intoIndexSql(d.index) }
+
+type ForeignKeyDef struct {
+	fk *schema.ForeignKey
+}
+
+func NewForeignKey(fk *schema.ForeignKey) ens.ForeignKeyDef {
+	return &ForeignKeyDef{fk: fk}
+}
+
+func (d *ForeignKeyDef) ForeignKey() *schema.ForeignKey { return d.fk }
+
+func (d *ForeignKeyDef) Definition() string { return intoForeignKeySql(d.fk) }
@@ -0,0 +1,238 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/internal/insql"
+	"github.com/things-go/ens/utils"
+)
+
+func intoColumnSql(col *schema.Column) string {
+	b := &strings.Builder{}
+	b.Grow(64)
+	b.WriteString(col.Type.Raw)
+	if !col.Type.Null {
+		b.WriteString(" NOT NULL")
+	}
+	// AUTOINCREMENT is only allowed on an INTEGER PRIMARY KEY.
+	if autoIncrement(col.Attrs) {
+		b.WriteString(" PRIMARY KEY AUTOINCREMENT")
+	}
+	switch x := schema.UnderlyingExpr(col.Default).(type) {
+	case *schema.Literal:
+		fmt.Fprintf(b, " DEFAULT %s", x.V)
+	case *schema.RawExpr:
+		fmt.Fprintf(b, " DEFAULT (%s)", strings.TrimSuffix(strings.TrimPrefix(x.X, "("), ")"))
+	default:
+		// do nothing
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func intoIndexSql(index *schema.Index) string {
+	fields := insql.IndexPartColumnNames(index.Parts)
+	fieldList := "`" + strings.Join(fields, "`,`") + "`"
+	if insql.IndexEqual(index.Table.PrimaryKey, index) {
+		return fmt.Sprintf("PRIMARY KEY (%s)", fieldList)
+	} else if index.Unique {
+		return fmt.Sprintf("CREATE UNIQUE INDEX `%s` ON `%s` (%s)", index.Name, index.Table.Name, fieldList)
+	} else {
+		return fmt.Sprintf("CREATE INDEX `%s` ON `%s` (%s)", index.Name, index.Table.Name, fieldList)
+	}
+}
+
+func intoForeignKeySql(fk *schema.ForeignKey) string {
+	columnNameList := "`" + strings.Join(insql.ColumnNames(fk.Columns), "`,`") + "`"
+	refColumnNameList := "`" + strings.Join(insql.ColumnNames(fk.RefColumns), "`,`") + "`"
+	b := &strings.Builder{}
+	b.Grow(128)
+	if fk.Symbol != "" {
+		fmt.Fprintf(b, "CONSTRAINT `%s` ", fk.Symbol)
+	}
+	fmt.Fprintf(b, "FOREIGN KEY (%s) REFERENCES `%s` (%s)", columnNameList, fk.RefTable.Name, refColumnNameList)
+	if fk.OnUpdate != "" {
+		fmt.Fprintf(b, " ON UPDATE %s", fk.OnUpdate)
+	}
+	if fk.OnDelete != "" {
+		fmt.Fprintf(b, " ON DELETE %s", fk.OnDelete)
+	}
+	return b.String()
+}
+
+func intoTableSql(tb *schema.Table) string {
+	b := &strings.Builder{}
+	b.Grow(256)
+	fmt.Fprintf(b, "CREATE TABLE `%s` (\n", tb.Name)
+
+	pkCol, inlinePk := inlinePrimaryKey(tb)
+	remain := len(tb.Columns) + len(tb.ForeignKeys)
+	if tb.PrimaryKey != nil && !inlinePk {
+		remain++
+	}
+	suffixOrEmpty := func(r int) string {
+		if r == 0 {
+			return ""
+		}
+		return ","
+	}
+	//* columns
+	for _, col := range tb.Columns {
+		remain--
+		suffix := suffixOrEmpty(remain)
+		definition := intoColumnSql(col)
+		if inlinePk && pkCol.Name == col.Name && !autoIncrement(col.Attrs) {
+			definition += " PRIMARY KEY"
+		}
+		fmt.Fprintf(b, "  `%s` %s%s\n", col.Name, definition, suffix)
+	}
+	//* pk
+	if tb.PrimaryKey != nil && !inlinePk {
+		remain--
+		suffix := suffixOrEmpty(remain)
+		fmt.Fprintf(b, "  %s%s\n", intoIndexSql(tb.PrimaryKey), suffix)
+	}
+	//* foreignKeys
+	for _, fk := range tb.ForeignKeys {
+		remain--
+		suffix := suffixOrEmpty(remain)
+		fmt.Fprintf(b, "  %s%s\n", intoForeignKeySql(fk), suffix)
+	}
+	b.WriteString(")")
+	//* indexes, sqlite not support inline index.
+	for _, index := range tb.Indexes {
+		if insql.IndexEqual(tb.PrimaryKey, index) { // ignore primary key, maybe include
+			continue
+		}
+		fmt.Fprintf(b, ";\n%s", intoIndexSql(index))
+	}
+	return b.String()
+}
+
+// column, type, not null, authIncrement, default, [primaryKey|index]
+func intoGormTag(tb *schema.Table, col *schema.Column) string {
+	pkPriority, isPk := 0, false
+	if pk := tb.PrimaryKey; pk != nil {
+		pkPriority, isPk = insql.FindIndexPartSeq(pk.Parts, col)
+	}
+	autoIncrement := autoIncrement(col.Attrs)
+
+	b := &strings.Builder{}
+	b.Grow(64)
+	fmt.Fprintf(b, `gorm:"column:%s`, col.Name)
+	if col.Type.Raw != "" && (!isPk || !autoIncrement) {
+		fmt.Fprintf(b, ";type:%s", col.Type.Raw)
+	}
+	if !col.Type.Null {
+		fmt.Fprintf(b, ";not null")
+	}
+
+	if isPk {
+		if autoIncrement {
+			fmt.Fprintf(b, ";autoIncrement:true")
+		}
+	} else {
+		dv := ""
+		switch x := schema.UnderlyingExpr(col.Default).(type) {
+		case *schema.Literal:
+			dv = x.V
+		case *schema.RawExpr:
+			dv = x.X
+		case nil:
+			if col.Type.Null {
+				dv = "null"
+			}
+		default:
+			// do nothing
+		}
+		if dv != "" {
+			fmt.Fprintf(b, ";default:%s", dv)
+		}
+	}
+
+	//* pk + indexes
+	if isPk && tb.PrimaryKey != nil {
+		fmt.Fprintf(b, ";primaryKey")
+		if len(tb.PrimaryKey.Parts) > 1 {
+			fmt.Fprintf(b, ",priority:%d", pkPriority)
+		}
+	}
+	// sqlite inspector does not link the indexes to column, so look up from table.
+	for _, val := range tb.Indexes {
+		if insql.IndexEqual(tb.PrimaryKey, val) { // ignore primary key, may be include
+			continue
+		}
+		priority, ok := insql.FindIndexPartSeq(val.Parts, col)
+		if !ok {
+			continue
+		}
+		if val.Unique {
+			fmt.Fprintf(b, ";uniqueIndex:%s", val.Name)
+		} else {
+			fmt.Fprintf(b, ";index:%s", val.Name)
+		}
+		if len(val.Parts) > 1 {
+			fmt.Fprintf(b, ",priority:%d", priority)
+		}
+	}
+	if comment, ok := insql.Comment(col.Attrs); ok && comment != "" {
+		fmt.Fprintf(b, ";comment:%s", utils.TrimFieldComment(comment))
+	}
+	b.WriteString(`"`)
+	return b.String()
+}
+
+func intoSchema(tb *schema.Table) *ens.EntityDescriptor {
+	// * columns
+	fielders := make([]*ens.FieldDescriptor, 0, len(tb.Columns))
+	for _, col := range tb.Columns {
+		goType, goPointer, customType := intoFieldType(col)
+		fielders = append(fielders, &ens.FieldDescriptor{
+			ColumnName: col.Name,
+			Comment:    insql.MustComment(col.Attrs),
+			Nullable:   col.Type.Null,
+			Column:     NewColumnDef(col),
+			Type:       goType,
+			GoName:     utils.PascalCase(col.Name),
+			GoPointer:  goPointer,
+			CustomType: customType,
+			Tags:       []string{intoGormTag(tb, col)},
+		})
+	}
+	// * indexes
+	indexers := make([]*ens.IndexDescriptor, 0, len(tb.Indexes))
+	for _, index := range tb.Indexes {
+		indexers = append(indexers, &ens.IndexDescriptor{
+			Name:   index.Name,
+			Fields: insql.IndexPartColumnNames(index.Parts),
+			Index:  NewIndexDef(index),
+		})
+	}
+	//* foreignKeys
+	fks := make([]*ens.ForeignKeyDescriptor, 0, len(tb.ForeignKeys))
+	for _, fk := range tb.ForeignKeys {
+		fks = append(fks, &ens.ForeignKeyDescriptor{
+			Symbol:     fk.Symbol,
+			Table:      fk.Table.Name,
+			Columns:    insql.ColumnNames(fk.Columns),
+			RefTable:   fk.RefTable.Name,
+			RefColumns: insql.ColumnNames(fk.RefColumns),
+			OnUpdate:   fk.OnUpdate,
+			OnDelete:   fk.OnDelete,
+			ForeignKey: NewForeignKey(fk),
+		})
+	}
+
+	// * table
+	return &ens.EntityDescriptor{
+		Name:        tb.Name,
+		Comment:     insql.MustComment(tb.Attrs),
+		Table:       NewTableDef(tb),
+		Fields:      fielders,
+		Indexes:     indexers,
+		ForeignKeys: fks,
+	}
+}
@@ -0,0 +1,21 @@
+package sqlite
+
+import (
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlite"
+
+	"github.com/things-go/ens/internal/insql"
+)
+
+func autoIncrement(attrs []schema.Attr) bool {
+	return insql.Has(attrs, &sqlite.AutoIncrement{})
+}
+
+// inlinePrimaryKey reports whether the table primary key can be declared inline with column,
+// only single column primary key can do it.
+func inlinePrimaryKey(tb *schema.Table) (*schema.Column, bool) {
+	if pk := tb.PrimaryKey; pk != nil && len(pk.Parts) == 1 && pk.Parts[0].C != nil {
+		return pk.Parts[0].C, true
+	}
+	return nil, false
+}
@@ -0,0 +1,10 @@
+package sqlite
+
+import (
+	"github.com/things-go/ens/driver"
+)
+
+func init() {
+	driver.RegisterDriver(driver.Sqlite, &SQLite{})
+	driver.RegisterDriver(driver.FileSqlite, &SQL{})
+}
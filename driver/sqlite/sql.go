@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlite"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+)
+
+var _ driver.Driver = (*SQL)(nil)
+
+// SQL parse sqlite ddl, it executes the ddl in an in-memory database,
+// and inspect it, so the result is exactly the same as sqlite understands.
+type SQL struct{}
+
+// InspectSchema implements driver.Driver.
+func (sq *SQL) InspectSchema(ctx context.Context, arg *driver.InspectOption) (*ens.Schema, error) {
+	sc, err := sq.inspectSchema(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	sc.Name = ""
+	return intoSchemaes(sc), nil
+}
+
+func (sq *SQL) inspectSchema(ctx context.Context, arg *driver.InspectOption) (*schema.Schema, error) {
+	if !isDriverRegistered() {
+		return nil, errDriverNotRegistered
+	}
+	db, err := sql.Open(sqlite.DriverName, "file:ens?mode=memory")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	// every connection of in-memory database is a new database, so only hold one.
+	db.SetMaxOpenConns(1)
+
+	if _, err = db.ExecContext(ctx, arg.Data); err != nil {
+		return nil, err
+	}
+	drv, err := sqlite.Open(db)
+	if err != nil {
+		return nil, err
+	}
+	return drv.InspectSchema(ctx, "", &arg.InspectOptions)
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func Test_intoGoType(t *testing.T) {
+	tests := []struct {
+		columnType string
+		want       ens.GoType
+	}{
+		{"INTEGER", ens.Int64Type()},
+		{"bigint", ens.Int64Type()},
+		{"int", ens.Int32Type()},
+		{"tinyint(1)", ens.BoolType()},
+		{"smallint unsigned", ens.Uint16Type()},
+		{"varchar(255)", ens.StringType()},
+		{"TEXT", ens.StringType()},
+		{"CLOB", ens.StringType()},
+		{"", ens.BytesType()},
+		{"blob", ens.BytesType()},
+		{"REAL", ens.Float64Type()},
+		{"double precision", ens.Float64Type()},
+		{"boolean", ens.BoolType()},
+		{"datetime", ens.TimeType()},
+		{"json", ens.JSONRawMessageType()},
+		{"decimal(10,2)", ens.DecimalType()},
+		{"NUMERIC", ens.DecimalType()},
+		{"uuid", UUIDType()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.columnType, func(t *testing.T) {
+			if got := intoGoType(tt.columnType); got != tt.want {
+				t.Errorf("intoGoType() = %v, want %v", got.Ident, tt.want.Ident)
+			}
+		})
+	}
+}
+
+func Test_SQL_Parse(t *testing.T) {
+	sql := "CREATE TABLE `users` (" +
+		"`id` INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT," +
+		"`name` varchar(64) NOT NULL DEFAULT ''," +
+		"`age` integer," +
+		"`created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP" +
+		");" +
+		"CREATE UNIQUE INDEX `uk_users_name` ON `users` (`name`);" +
+		"CREATE TABLE `posts` (" +
+		"`id` integer NOT NULL," +
+		"`user_id` integer NOT NULL," +
+		"PRIMARY KEY (`id`)," +
+		"CONSTRAINT `fk_posts_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE" +
+		");"
+
+	d := &SQL{}
+	sc, err := d.InspectSchema(context.Background(), &driver.InspectOption{Data: sql})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sc.Entities) != 2 {
+		t.Fatalf("entities count = %d, want 2", len(sc.Entities))
+	}
+	users := sc.Entities[1]
+	if users.Name != "users" {
+		users = sc.Entities[0]
+	}
+	if got, want := users.Fields[0].Tags[0], `gorm:"column:id;not null;autoIncrement:true;primaryKey"`; got != want {
+		t.Errorf("gorm tag = %v, want %v", got, want)
+	}
+	if got, want := users.Fields[1].Tags[0], `gorm:"column:name;type:varchar(64);not null;default:'';uniqueIndex:uk_users_name"`; got != want {
+		t.Errorf("gorm tag = %v, want %v", got, want)
+	}
+	if !users.Fields[2].Nullable || users.Fields[2].Type != ens.Int64Type() {
+		t.Errorf("unexpected field: %+v", users.Fields[2])
+	}
+	definition := users.Table.Definition()
+	for _, want := range []string{
+		"`id` INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,",
+		"`created_at` datetime NOT NULL DEFAULT (CURRENT_TIMESTAMP)",
+		"CREATE UNIQUE INDEX `uk_users_name` ON `users` (`name`)",
+	} {
+		if !strings.Contains(definition, want) {
+			t.Errorf("definition missing %q, got:\n%s", want, definition)
+		}
+	}
+	// the in-memory database is re-created for every inspect.
+	if _, err = d.InspectSchema(context.Background(), &driver.InspectOption{Data: sql}); err != nil {
+		t.Fatal(err)
+	}
+}
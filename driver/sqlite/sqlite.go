@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlclient"
+	"ariga.io/atlas/sql/sqlite"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+)
+
+var _ driver.Driver = (*SQLite)(nil)
+
+// errDriverNotRegistered the database/sql driver "sqlite3" is provided by cgo package,
+// like github.com/mattn/go-sqlite3, it is not imported by ens.
+var errDriverNotRegistered = errors.New(`sqlite: database/sql driver "sqlite3" not registered, please import it, like: _ "github.com/mattn/go-sqlite3"`)
+
+type SQLite struct{}
+
+// InspectSchema implements driver.Driver.
+func (sl *SQLite) InspectSchema(ctx context.Context, arg *driver.InspectOption) (*ens.Schema, error) {
+	schemaes, err := sl.inspectSchema(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	return intoSchemaes(schemaes), nil
+}
+
+func (sl *SQLite) inspectSchema(ctx context.Context, arg *driver.InspectOption) (*schema.Schema, error) {
+	if !isDriverRegistered() {
+		return nil, errDriverNotRegistered
+	}
+	client, err := sqlclient.Open(ctx, arg.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return client.InspectSchema(ctx, "", &arg.InspectOptions)
+}
+
+func isDriverRegistered() bool {
+	return slices.Contains(sql.Drivers(), sqlite.DriverName)
+}
+
+func intoSchemaes(sc *schema.Schema) *ens.Schema {
+	entities := make([]*ens.EntityDescriptor, 0, len(sc.Tables))
+	for _, tb := range sc.Tables {
+		entities = append(entities, intoSchema(tb))
+	}
+	return &ens.Schema{
+		Name:     sc.Name,
+		Entities: entities,
+	}
+}
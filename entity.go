@@ -1,10 +1,13 @@
 package ens
 
 import (
+	"slices"
+
 	"github.com/things-go/ens/proto"
 	"github.com/things-go/ens/rapier"
 	"github.com/things-go/ens/sqlx"
 	"github.com/things-go/ens/utils"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // EntityDescriptor Each table corresponds to an EntityDescriptor
@@ -19,14 +22,44 @@ type EntityDescriptor struct {
 
 func (s *EntityDescriptor) IntoProto() *proto.Message {
 	fields := make([]*proto.MessageField, 0, len(s.Fields))
+	enums := make([]*proto.Enum, 0, 4)
 	for _, field := range s.Fields {
-		fields = append(fields, field.IntoProto())
+		mf := field.IntoProto()
+		if mf.Type != protoreflect.EnumKind && mf.Type != protoreflect.MessageKind {
+			if enum := field.intoProtoEnum(s.Name); enum != nil {
+				mf.Type, mf.TypeName = protoreflect.EnumKind, enum.Name
+				enums = append(enums, enum)
+			}
+		}
+		fields = append(fields, mf)
+	}
+	resolveProtoEnums(fields, enums)
+	var primaryKeys, indexColumns []string
+	if s.Table != nil {
+		if pk := s.Table.PrimaryKey(); pk != nil && pk.Index() != nil {
+			for _, part := range pk.Index().Parts {
+				if part.C != nil {
+					primaryKeys = append(primaryKeys, part.C.Name)
+				}
+			}
+		}
+	}
+	for _, index := range s.Indexes {
+		for _, column := range index.Fields {
+			if !slices.Contains(primaryKeys, column) && !slices.Contains(indexColumns, column) {
+				indexColumns = append(indexColumns, column)
+			}
+		}
 	}
 	return &proto.Message{
 		Name:      s.Name,
 		TableName: s.Name,
 		Comment:   s.Comment,
 		Fields:    fields,
+		Enums:     enums,
+		// for service
+		PrimaryKeys:  primaryKeys,
+		IndexColumns: indexColumns,
 	}
 }
 func (s *EntityDescriptor) IntoRapier() *rapier.Struct {
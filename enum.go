@@ -0,0 +1,182 @@
+package ens
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/things-go/ens/matcher"
+	"github.com/things-go/ens/proto"
+	"github.com/things-go/ens/utils"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// EnumDescriptor the typed enum of the field.
+type EnumDescriptor struct {
+	Name   string       // Go type name, like UserGender
+	Type   GoType       // underlying go type, string or integer
+	Values []*EnumValue // 枚举值
+}
+
+// EnumValue the enum value.
+type EnumValue struct {
+	Name    string // constant name, like UserGenderMale
+	Value   string // go literal value, like 1, "male"
+	Comment string // 注释
+}
+
+// IsInteger reports whether the underlying type of the enum is integer.
+func (e *EnumDescriptor) IsInteger() bool {
+	return e.Type.Type.IsInteger()
+}
+
+// enumValues returns the enum values of the field, the comment annotation `[@enum: 1:Male:男]`
+// is preferred, otherwise the values of the enum column.
+func (field *FieldDescriptor) enumValues() []matcher.EnumValue {
+	if values := matcher.Enum(field.Comment); len(values) > 0 {
+		return values
+	}
+	if field.Column == nil || field.Column.Column() == nil || field.Column.Column().Type == nil {
+		return nil
+	}
+	t, ok := field.Column.Column().Type.Type.(*schema.EnumType)
+	if !ok || len(t.Values) == 0 {
+		return nil
+	}
+	values := make([]matcher.EnumValue, 0, len(t.Values))
+	for _, v := range t.Values {
+		values = append(values, matcher.EnumValue{Value: v})
+	}
+	return values
+}
+
+// intoEnum returns the enum descriptor of the field with the enum values, nil if the field type can not be an enum.
+// NOTE: the field must be fixed by fixField.
+func (field *FieldDescriptor) intoEnum(entityName string, values []matcher.EnumValue) *EnumDescriptor {
+	// sql.NullXXX, soft_delete.DeletedAt ...
+	if len(values) == 0 || field.Type.PkgPath != "" {
+		return nil
+	}
+	isInteger := field.Type.Type.IsInteger()
+	if !isInteger && field.Type.Ident != "string" {
+		return nil
+	}
+	name := utils.PascalCase(entityName) + field.GoName
+	enum := &EnumDescriptor{
+		Name:   name,
+		Type:   field.Type,
+		Values: make([]*EnumValue, 0, len(values)),
+	}
+	used := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		value := strconv.Quote(v.Value)
+		if isInteger {
+			if _, err := strconv.ParseInt(v.Value, 10, 64); err != nil {
+				return nil
+			}
+			value = v.Value
+		}
+		valueName := v.Name
+		if valueName == "" {
+			valueName = enumValueName(v.Value)
+		}
+		for { // 名称重复时加 X 前缀
+			if _, ok := used[valueName]; !ok {
+				break
+			}
+			valueName = "X" + valueName
+		}
+		used[valueName] = struct{}{}
+		enum.Values = append(enum.Values, &EnumValue{
+			Name:    name + valueName,
+			Value:   value,
+			Comment: v.Comment,
+		})
+	}
+	field.Type = GoType{
+		Type:         field.Type.Type,
+		Ident:        name,
+		PkgPath:      "",
+		PkgQualifier: "",
+		NonPointer:   false,
+	}
+	return enum
+}
+
+// enumValueName returns the Go name of the enum value, like `in_progress` -> `InProgress`, `1` -> `V1`.
+func enumValueName(value string) string {
+	s := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, value)
+	name := utils.PascalCase(s)
+	if name == "" {
+		return "Empty"
+	}
+	if r := []rune(name)[0]; !unicode.IsLetter(r) {
+		name = "V" + name
+	}
+	return name
+}
+
+// intoProtoEnum returns the proto enum of the field, nil if the field has no enum values.
+// the enum value number is the integer value, otherwise the sequence start from 1.
+func (field *FieldDescriptor) intoProtoEnum(entityName string) *proto.Enum {
+	values := field.enumValues()
+	if len(values) == 0 {
+		return nil
+	}
+	name := utils.PascalCase(entityName) + utils.PascalCase(field.ColumnName)
+	prefix := strings.ToUpper(utils.SnakeCase(name)) + "_"
+	enum := &proto.Enum{
+		Name:    name,
+		Comment: field.Comment,
+		Values:  make([]*proto.EnumValue, 0, len(values)),
+	}
+	used := make(map[string]struct{}, len(values))
+	for i, v := range values {
+		number := int64(i + 1)
+		if field.Type.Type.IsInteger() {
+			n, err := strconv.ParseInt(v.Value, 10, 32)
+			if err != nil {
+				return nil
+			}
+			number = n
+		}
+		valueName := v.Name
+		if valueName == "" {
+			valueName = enumValueName(v.Value)
+		}
+		valueName = strings.ToUpper(utils.SnakeCase(valueName))
+		for { // 名称重复时加 X 前缀
+			if _, ok := used[valueName]; !ok {
+				break
+			}
+			valueName = "X" + valueName
+		}
+		used[valueName] = struct{}{}
+		enum.Values = append(enum.Values, &proto.EnumValue{
+			Name:    prefix + valueName,
+			Number:  int32(number),
+			Comment: v.Comment,
+		})
+	}
+	return enum
+}
+
+// resolveProtoEnums mark the field which references the generated enum by `[@pbtype: xxx]` as the enum.
+func resolveProtoEnums(fields []*proto.MessageField, enums []*proto.Enum) {
+	for _, mf := range fields {
+		if mf.Type != protoreflect.MessageKind {
+			continue
+		}
+		if slices.ContainsFunc(enums, func(e *proto.Enum) bool { return e.Name == mf.TypeName }) {
+			mf.Type = protoreflect.EnumKind
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package ens_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/proto"
+)
+
+func Test_Enum(t *testing.T) {
+	sc := parseSQL(t, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `status` enum('active','in_progress') NOT NULL DEFAULT 'active' COMMENT '状态',\n"+
+		"  `sex` tinyint NOT NULL DEFAULT '0' COMMENT '性别 [@enum: 1:Male:男,2:Female:女]',\n"+
+		"  `level` tinyint NOT NULL DEFAULT '0' COMMENT '等级 [@enum: a,b]',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+
+	g := &ens.CodeGen{
+		Entities:          sc.Entities,
+		ByName:            "ormat",
+		Version:           "v1.0.0",
+		PackageName:       "model",
+		DisableDocComment: true,
+		Option: ens.Option{
+			Tags: map[string]string{"json": "snakeCase"},
+		},
+	}
+	data, err := g.Gen().FormatSource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(strings.Fields(string(data)), " ")
+	for _, want := range []string{
+		"Status UserStatus `gorm:",
+		"Sex UserSex `gorm:",
+		"Level int8 `gorm:", // 整型的枚举值必须为整数
+		"type UserStatus string",
+		`UserStatusActive UserStatus = "active"`,
+		`UserStatusInProgress UserStatus = "in_progress"`,
+		`case UserStatusInProgress: return "in_progress"`,
+		"type UserSex int8",
+		"UserSexMale UserSex = 1 // 男",
+		`case UserSexFemale: return "女"`,
+		`return fmt.Sprintf("UserSex(%v)", int8(v))`,
+		"func (v UserSex) IsValid() bool",
+		"var n sql.NullInt64",
+		"*v = UserSex(n.Int64)",
+		"return int64(v), nil",
+		"var n sql.NullString",
+		"return string(v), nil",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, data)
+		}
+	}
+	if strings.Contains(got, "type UserLevel") {
+		t.Errorf("unexpected enum UserLevel in\n%s", data)
+	}
+}
+
+func Test_ProtoEnum(t *testing.T) {
+	sc := parseSQL(t, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `status` enum('active','in_progress') NOT NULL DEFAULT 'active' COMMENT '状态',\n"+
+		"  `sex` tinyint NOT NULL DEFAULT '0' COMMENT '性别 [@enum: 1:Male:男,2:Female:女]',\n"+
+		"  `level` tinyint NOT NULL DEFAULT '0' COMMENT '等级 [@enum: 0:Normal,1:Vip]',\n"+
+		"  `kind` int NOT NULL DEFAULT '0' COMMENT '类型 [@pbtype: E.Kind]',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+
+	g := &proto.CodeGen{
+		Messages:          sc.IntoProto().Entities,
+		ByName:            "ormat",
+		Version:           "v1.0.0",
+		PackageName:       "api",
+		DisableDocComment: true,
+	}
+	got := strings.Join(strings.Fields(string(g.Gen().Bytes())), " ")
+	for _, want := range []string{
+		"enum UserStatus { USER_STATUS_UNSPECIFIED = 0; USER_STATUS_ACTIVE = 1; USER_STATUS_IN_PROGRESS = 2; }",
+		"enum UserSex { USER_SEX_UNSPECIFIED = 0; // 男 USER_SEX_MALE = 1; // 女 USER_SEX_FEMALE = 2; }",
+		"enum UserLevel { USER_LEVEL_NORMAL = 0; USER_LEVEL_VIP = 1; }",
+		"UserStatus status = 2;",
+		"UserSex sex = 3;",
+		"UserLevel level = 4;",
+		"E.Kind kind = 5;",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, g.Bytes())
+		}
+	}
+}
+
+func Test_ProtoEnum_PbType(t *testing.T) {
+	sc := parseSQL(t, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `status` tinyint NOT NULL COMMENT '状态 [@enum: 1:Active,2:Inactive]',\n"+
+		"  `gender` tinyint NOT NULL COMMENT '性别 [@enum: 1:Male,2:Female] [@pbtype: E.Gender]',\n"+
+		"  `profile` json NOT NULL COMMENT '资料 [@pbtype: Profile]',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;\n"+
+		"CREATE TABLE `order` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `user_status` tinyint NOT NULL COMMENT '用户状态 [@pbtype: UserStatus]',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+
+	g := &proto.CodeGen{
+		Messages:          sc.IntoProto().Entities,
+		ByName:            "ormat",
+		Version:           "v1.0.0",
+		PackageName:       "api",
+		DisableDocComment: true,
+		EnableValidate:    true,
+	}
+	got := strings.Join(strings.Fields(string(g.Gen().Bytes())), " ")
+	for _, want := range []string{
+		"UserStatus status = 2 [(buf.validate.field).required = true, (buf.validate.field).enum = {defined_only: true}];",
+		"E.Gender gender = 3 [(buf.validate.field).required = true, (buf.validate.field).enum = {defined_only: true}];",
+		"Profile profile = 4 [(buf.validate.field).required = true];",
+		"UserStatus user_status = 2 [(buf.validate.field).required = true, (buf.validate.field).enum = {defined_only: true}];",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, g.Bytes())
+		}
+	}
+	if strings.Contains(got, "enum UserGender") {
+		t.Errorf("the enum referenced by [@pbtype] should not be generated\n%s", g.Bytes())
+	}
+
+	cg := &ens.ConvertCodeGen{
+		Entities:        sc.Entities,
+		ByName:          "ormat",
+		Version:         "v1.0.0",
+		PackageName:     "convert",
+		ModelImportPath: "example.com/model",
+		PbImportPath:    "example.com/api/pb",
+	}
+	data, err := cg.Gen().FormatSource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = strings.Join(strings.Fields(string(data)), " ")
+	for _, want := range []string{
+		"// Profile: unsupported conversion, convert it manually",
+		"out.UserStatus = pb.UserStatus(m.UserStatus)",
+		"out.UserStatus = int8(p.UserStatus)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, data)
+		}
+	}
+}
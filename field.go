@@ -1,6 +1,15 @@
 package ens
 
 import (
+	"math"
+	"strings"
+
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlite"
+
+	"github.com/things-go/ens/internal/insql"
+	"github.com/things-go/ens/matcher"
 	"github.com/things-go/ens/proto"
 	"github.com/things-go/ens/rapier"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -12,10 +21,11 @@ type FieldDescriptor struct {
 	Nullable   bool   // Nullable reports whether the column may be null.
 	Column     ColumnDef
 	// for go
-	Type      GoType   // go type information.
-	GoName    string   // Go name
-	GoPointer bool     // go field is pointer.
-	Tags      []string // Tags struct tag
+	Type       GoType   // go type information.
+	GoName     string   // Go name
+	GoPointer  bool     // go field is pointer.
+	Tags       []string // Tags struct tag
+	CustomType bool     // go type is customized by `[@gotype: xxx]` or the config, used as is.
 }
 
 func (field *FieldDescriptor) GoType(typ any) {
@@ -29,6 +39,12 @@ func (field *FieldDescriptor) IntoProto() *proto.MessageField {
 	if field.Nullable {
 		cardinality = protoreflect.Optional
 	}
+	if typeName := matcher.PbType(field.Comment); typeName != "" { // 引用已定义的消息, 声明了枚举值或引用生成的枚举时为枚举
+		k, n = protoreflect.MessageKind, typeName
+		if len(field.enumValues()) > 0 {
+			k = protoreflect.EnumKind
+		}
+	}
 	return &proto.MessageField{
 		Cardinality: cardinality,
 		Type:        k,
@@ -36,7 +52,57 @@ func (field *FieldDescriptor) IntoProto() *proto.MessageField {
 		Name:        field.ColumnName,
 		ColumnName:  field.ColumnName,
 		Comment:     field.Comment,
+		Nullable:    matcher.PbNull(field.Comment),
+		Constraint:  field.intoConstraint(),
+	}
+}
+
+// integerRanges the range of the integer column type.
+var integerRanges = map[string][2][2]int64{ // type -> {signed{min, max}, unsigned{min, max}}
+	"tinyint":   {{math.MinInt8, math.MaxInt8}, {0, math.MaxUint8}},
+	"smallint":  {{math.MinInt16, math.MaxInt16}, {0, math.MaxUint16}},
+	"mediumint": {{-1 << 23, 1<<23 - 1}, {0, 1<<24 - 1}},
+	"int":       {{math.MinInt32, math.MaxInt32}, {0, math.MaxUint32}},
+	"integer":   {{math.MinInt32, math.MaxInt32}, {0, math.MaxUint32}},
+}
+
+func isAutoIncrement(col *schema.Column) bool {
+	return insql.Has(col.Attrs, &mysql.AutoIncrement{}) || insql.Has(col.Attrs, &sqlite.AutoIncrement{})
+}
+
+// intoConstraint returns the constraint of the field derived from the column metadata, nil if no column.
+func (field *FieldDescriptor) intoConstraint() *proto.Constraint {
+	if field.Column == nil || field.Column.Column() == nil {
+		return nil
+	}
+	col := field.Column.Column()
+	c := &proto.Constraint{
+		Required: !field.Nullable && col.Default == nil && !isAutoIncrement(col),
+	}
+	if col.Type == nil {
+		return c
+	}
+	switch t := col.Type.Type.(type) {
+	case *schema.StringType:
+		if tt := strings.ToLower(t.T); (tt == "char" || tt == "varchar") && t.Size > 0 {
+			c.MaxLen = int64(t.Size)
+		}
+	case *schema.BinaryType:
+		if tt := strings.ToLower(t.T); (tt == "binary" || tt == "varbinary") && t.Size != nil && *t.Size > 0 {
+			c.MaxLen = int64(*t.Size)
+		}
+	case *schema.IntegerType:
+		if r, ok := integerRanges[strings.ToLower(t.T)]; ok {
+			rr := r[0]
+			if t.Unsigned {
+				rr = r[1]
+			}
+			c.Min, c.Max = &rr[0], &rr[1]
+		}
+	case *schema.EnumType:
+		c.In = t.Values
 	}
+	return c
 }
 
 func (field *FieldDescriptor) IntoRapier() *rapier.StructField {
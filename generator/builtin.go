@@ -0,0 +1,310 @@
+package generator
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/proto"
+	"github.com/things-go/ens/rapier"
+	"github.com/things-go/ens/sqlx"
+	"github.com/things-go/ens/utils"
+)
+
+// builtin generators, same as the ormat commands.
+const (
+	Model   = "model"
+	SQL     = "sql"
+	Proto   = "proto"
+	Rapier  = "rapier"
+	Convert = "convert"
+)
+
+func init() {
+	RegisterGenerator(Model, GeneratorFunc(genModel))
+	RegisterGenerator(SQL, GeneratorFunc(genSQL))
+	RegisterGenerator(Proto, GeneratorFunc(genProto))
+	RegisterGenerator(Rapier, GeneratorFunc(genRapier))
+	RegisterGenerator(Convert, GeneratorFunc(genConvert))
+}
+
+// genModel options:
+//
+//	enableInt, enableBoolInt, disableNullToPoint, enableForeignKey, ignoreOmitempty: bool
+//	enableDatatypesDate, enableDatatypesTime, enableYearInt, enableDecimal, enableUUID, enableBitBool: bool, 可切换的列类型映射, see ens.TypeOption
+//	nullStyle: null类型风格 when disableNullToPoint, [sql,generic,datatypes] or custom generic wrapper, default sql, see ens.NullWrapperType
+//	escapeName: escape name list, separated by `,`
+//	tag.{name}: tag style, like `tag.json=snakeCase`, default `json` is snakeCase, `tag.binding=validator` for the validator rules
+//	field.{TableName}.{ColumnName}: custom field ident, the full identifier like `github.com/shopspring/decimal.Decimal`
+//	jsonType.{TableName}.{ColumnName}: json列绑定的类型, like `Settings`, `[]Tag`, `map`
+//	jsonSchema.{Type}: json schema file, generate the struct of the json column type into `json_types.go`
+func genModel(sc *ens.Schema, opt *Option) ([]*File, error) {
+	if _, err := ens.NullWrapperType(opt.String("nullStyle", ens.NullStyleSQL)); err != nil {
+		return nil, err
+	}
+	if err := applySchemaOption(sc, opt); err != nil {
+		return nil, err
+	}
+	files := make([]*File, 0, len(sc.Entities)+1)
+	for _, entity := range sc.Entities {
+		g := &ens.CodeGen{
+			Entities:          []*ens.EntityDescriptor{entity},
+			AllEntities:       sc.Entities,
+			ByName:            opt.ByName,
+			Version:           opt.Version,
+			PackageName:       cmp.Or(opt.PackageName, utils.GetPkgName(opt.OutputDir)),
+			DisableDocComment: opt.DisableDocComment,
+			Option:            modelOption(opt),
+		}
+		data, err := g.Gen().FormatSource()
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", entity.Name, err)
+		}
+		files = append(files, &File{Name: entity.Name + ".go", Data: data})
+	}
+	if jsonSchema := opt.Prefix("jsonSchema."); len(jsonSchema) > 0 {
+		schemas := make(map[string]*ens.JSONSchema, len(jsonSchema))
+		for name, file := range jsonSchema {
+			s, err := ens.LoadJSONSchema(file)
+			if err != nil {
+				return nil, err
+			}
+			schemas[name] = s
+		}
+		g := &ens.JSONSchemaCodeGen{
+			Schemas:           schemas,
+			ByName:            opt.ByName,
+			Version:           opt.Version,
+			PackageName:       cmp.Or(opt.PackageName, utils.GetPkgName(opt.OutputDir)),
+			DisableDocComment: opt.DisableDocComment,
+		}
+		data, err := g.Gen().FormatSource()
+		if err != nil {
+			return nil, fmt.Errorf("json schema: %w", err)
+		}
+		files = append(files, &File{Name: "json_types.go", Data: data})
+	}
+	return files, nil
+}
+
+// applySchemaOption apply the switchable mappings, the custom field ident and json type of the custom options to the schema,
+// so the model, proto, rapier and convert generators see the same field type.
+func applySchemaOption(sc *ens.Schema, opt *Option) error {
+	typeOption := modelTypeOption(opt)
+	sc.ApplyTypeOption(&typeOption)
+	if err := sc.OverrideGoType(tableColumnOption(opt.Prefix("field."))); err != nil {
+		return fmt.Errorf("field: %w", err)
+	}
+	if err := sc.OverrideJSONType(tableColumnOption(opt.Prefix("jsonType."))); err != nil {
+		return fmt.Errorf("jsonType: %w", err)
+	}
+	return nil
+}
+
+// tableColumnOption convert `{TableName}.{ColumnName}: value` to `TableName -> ColumnName -> value`.
+func tableColumnOption(m map[string]string) map[string]map[string]string {
+	result := make(map[string]map[string]string)
+	for key, value := range m {
+		tb, column, ok := strings.Cut(key, ".")
+		if !ok || tb == "" || column == "" || value == "" {
+			continue
+		}
+		if result[tb] == nil {
+			result[tb] = make(map[string]string)
+		}
+		result[tb][column] = value
+	}
+	return result
+}
+
+// genSQL options:
+//
+//	merge: bool, merge in a file
+//	filename: filename when merge enabled, default `create_table`
+func genSQL(sc *ens.Schema, opt *Option) ([]*File, error) {
+	schemaes := sc.IntoSQL()
+	if opt.Bool("merge") {
+		codegen := &sqlx.CodeGen{
+			Entities:          schemaes.Entities,
+			ByName:            opt.ByName,
+			Version:           opt.Version,
+			DisableDocComment: opt.DisableDocComment,
+		}
+		return []*File{
+			{Name: opt.String("filename", "create_table") + ".sql", Data: codegen.Gen().Bytes()},
+		}, nil
+	}
+	files := make([]*File, 0, len(schemaes.Entities))
+	for _, entity := range schemaes.Entities {
+		codegen := &sqlx.CodeGen{
+			Entities:          []*sqlx.Table{entity},
+			ByName:            opt.ByName,
+			Version:           opt.Version,
+			DisableDocComment: opt.DisableDocComment,
+		}
+		files = append(files, &File{Name: entity.Name + ".sql", Data: codegen.Gen().Bytes()})
+	}
+	return files, nil
+}
+
+// genProto options:
+//
+//	style: 字段代码风格, snakeCase, smallCamelCase, pascalCase
+//	disableBool, disableTimestamp, enableOpenapiv2Annotation, enableService, enableHttpAnnotation, enableValidate: bool
+//	option.{key}: proto option, like `option.go_package=example.com/api`
+//	nullable: 可空字段的默认策略, optional(default), wrapper, none
+//	lockFile: 字段编号锁文件, 相对于输出目录, default `proto.lock`
+//	disableLock: bool, 禁用字段编号锁
+//	enableDatatypesDate, enableDatatypesTime, enableYearInt, enableDecimal, enableUUID, enableBitBool, field, jsonType: same as the model generator
+func genProto(sc *ens.Schema, opt *Option) ([]*File, error) {
+	var err error
+	var lock *proto.Lock
+
+	if err = applySchemaOption(sc, opt); err != nil {
+		return nil, err
+	}
+	protoSchemaes := sc.IntoProto()
+	files := make([]*File, 0, len(protoSchemaes.Entities)+1)
+	lockFile := opt.String("lockFile", "proto.lock")
+	if !opt.Bool("disableLock") {
+		lock, err = proto.LoadLock(filepath.Join(opt.OutputDir, lockFile))
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, msg := range protoSchemaes.Entities {
+		codegen := &proto.CodeGen{
+			Messages:                  []*proto.Message{msg},
+			ByName:                    opt.ByName,
+			Version:                   opt.Version,
+			PackageName:               cmp.Or(opt.PackageName, utils.GetPkgName(opt.OutputDir)),
+			Options:                   opt.Prefix("option."),
+			Style:                     opt.String("style", ""),
+			DisableDocComment:         opt.DisableDocComment,
+			DisableBool:               opt.Bool("disableBool"),
+			DisableTimestamp:          opt.Bool("disableTimestamp"),
+			EnableOpenapiv2Annotation: opt.Bool("enableOpenapiv2Annotation"),
+			EnableService:             opt.Bool("enableService"),
+			EnableHttpAnnotation:      opt.Bool("enableHttpAnnotation"),
+			Lock:                      lock,
+			Nullable:                  opt.String("nullable", ""),
+			EnableValidate:            opt.Bool("enableValidate"),
+		}
+		files = append(files, &File{Name: msg.TableName + ".proto", Data: codegen.Gen().Bytes()})
+	}
+	if lock != nil {
+		data, err := lock.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, &File{Name: lockFile, Data: data})
+	}
+	return files, nil
+}
+
+// genRapier options:
+//
+//	modelImportPath: model导入路径
+//	enableInt, enableBoolInt: bool
+//	enableDatatypesDate, enableDatatypesTime, enableYearInt, enableDecimal, enableUUID, enableBitBool, field, jsonType: same as the model generator
+func genRapier(sc *ens.Schema, opt *Option) ([]*File, error) {
+	if err := applySchemaOption(sc, opt); err != nil {
+		return nil, err
+	}
+	rapierSchemaes := sc.IntoRapier()
+	files := make([]*File, 0, len(rapierSchemaes.Entities))
+	for _, entity := range rapierSchemaes.Entities {
+		codegen := &rapier.CodeGen{
+			Entities:          []*rapier.Struct{entity},
+			ByName:            opt.ByName,
+			Version:           opt.Version,
+			PackageName:       cmp.Or(opt.PackageName, utils.GetPkgName(opt.OutputDir)),
+			ModelImportPath:   opt.String("modelImportPath", ""),
+			DisableDocComment: opt.DisableDocComment,
+			EnableInt:         opt.Bool("enableInt"),
+			EnableBoolInt:     opt.Bool("enableBoolInt"),
+		}
+		data, err := codegen.Gen().FormatSource()
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", entity.TableName, err)
+		}
+		files = append(files, &File{Name: entity.TableName + ".rapier.gen.go", Data: data})
+	}
+	return files, nil
+}
+
+// genConvert generate the converters between the model and the protobuf message, options:
+//
+//	modelImportPath: model导入路径, 必填
+//	pbImportPath: protobuf go导入路径, 必填
+//	enableInt, enableBoolInt, disableNullToPoint, nullStyle, escapeName, enableDatatypesDate..., field, jsonType: same as the model generator
+//	style, disableBool, disableTimestamp, nullable: same as the proto generator
+func genConvert(sc *ens.Schema, opt *Option) ([]*File, error) {
+	modelImportPath, pbImportPath := opt.String("modelImportPath", ""), opt.String("pbImportPath", "")
+	if modelImportPath == "" || pbImportPath == "" {
+		return nil, fmt.Errorf("convert: modelImportPath and pbImportPath are required")
+	}
+	if err := applySchemaOption(sc, opt); err != nil {
+		return nil, err
+	}
+	files := make([]*File, 0, len(sc.Entities))
+	for _, entity := range sc.Entities {
+		codegen := &ens.ConvertCodeGen{
+			Entities:          []*ens.EntityDescriptor{entity},
+			ByName:            opt.ByName,
+			Version:           opt.Version,
+			PackageName:       cmp.Or(opt.PackageName, utils.GetPkgName(opt.OutputDir)),
+			ModelImportPath:   modelImportPath,
+			PbImportPath:      pbImportPath,
+			DisableDocComment: opt.DisableDocComment,
+			Option:            modelOption(opt),
+			Style:             opt.String("style", ""),
+			DisableBool:       opt.Bool("disableBool"),
+			DisableTimestamp:  opt.Bool("disableTimestamp"),
+			Nullable:          opt.String("nullable", ""),
+		}
+		data, err := codegen.Gen().FormatSource()
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", entity.Name, err)
+		}
+		files = append(files, &File{Name: entity.Name + ".convert.go", Data: data})
+	}
+	return files, nil
+}
+
+// modelOption returns the model option from the custom options.
+func modelOption(opt *Option) ens.Option {
+	tags := opt.Prefix("tag.")
+	if len(tags) == 0 {
+		tags = map[string]string{"json": utils.StyleSnakeCase}
+	}
+	var escapeName []string
+	if v := opt.String("escapeName", ""); v != "" {
+		escapeName = strings.Split(v, ",")
+	}
+	return ens.Option{
+		EnableInt:          opt.Bool("enableInt"),
+		EnableBoolInt:      opt.Bool("enableBoolInt"),
+		DisableNullToPoint: opt.Bool("disableNullToPoint"),
+		NullStyle:          opt.String("nullStyle", ens.NullStyleSQL),
+		EnableForeignKey:   opt.Bool("enableForeignKey"),
+		IgnoreOmitempty:    opt.Bool("ignoreOmitempty"),
+		Tags:               tags,
+		EscapeName:         escapeName,
+		TypeOption:         modelTypeOption(opt),
+	}
+}
+
+// modelTypeOption returns the switchable mappings of the column types from the custom options.
+func modelTypeOption(opt *Option) ens.TypeOption {
+	return ens.TypeOption{
+		EnableDatatypesDate: opt.Bool("enableDatatypesDate"),
+		EnableDatatypesTime: opt.Bool("enableDatatypesTime"),
+		EnableYearInt:       opt.Bool("enableYearInt"),
+		EnableDecimal:       opt.Bool("enableDecimal"),
+		EnableUUID:          opt.Bool("enableUUID"),
+		EnableBitBool:       opt.Bool("enableBitBool"),
+	}
+}
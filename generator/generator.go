@@ -0,0 +1,111 @@
+// Package generator provides the pluggable generators like driver.RegisterDriver.
+//
+// A third-party generator register itself in init, and a custom main wraps command.NewRootCmd:
+//
+//	import (
+//		"github.com/things-go/ens/cmd/ormat/command"
+//		"github.com/things-go/ens/generator"
+//	)
+//
+//	func init() {
+//		generator.RegisterGenerator("foo", generator.GeneratorFunc(genFoo))
+//	}
+//
+//	func main() {
+//		command.NewRootCmd().Execute()
+//	}
+//
+// then run it with `ormat gen --target=foo`.
+package generator
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/things-go/ens"
+)
+
+var generators sync.Map
+
+// File generated file.
+type File struct {
+	Name string // 文件名, 相对于输出目录
+	Data []byte // 文件内容
+}
+
+// Option generator option.
+type Option struct {
+	ByName            string            // required, 生成名称
+	Version           string            // required, 生成版本
+	OutputDir         string            // 输出目录
+	PackageName       string            // 包名, 为空时由输出目录推导
+	DisableDocComment bool              // 禁用doc注释
+	Options           map[string]string // 生成器自定义选项
+}
+
+// Generator generate files from the schema.
+type Generator interface {
+	Generate(*ens.Schema, *Option) ([]*File, error)
+}
+
+// GeneratorFunc is an adapter to allow the use of ordinary functions as Generator.
+type GeneratorFunc func(*ens.Schema, *Option) ([]*File, error)
+
+// Generate implement Generator interface.
+func (f GeneratorFunc) Generate(sc *ens.Schema, opt *Option) ([]*File, error) {
+	return f(sc, opt)
+}
+
+func RegisterGenerator(name string, g Generator) {
+	if _, ok := generators.Load(name); ok {
+		panic("generator: Register called twice for " + name)
+	}
+	generators.Store(name, g)
+}
+
+func LoadGenerator(name string) (Generator, error) {
+	g, ok := generators.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("unsupported generator, only support [%v]", strings.Join(GeneratorNames(), ", "))
+	}
+	return g.(Generator), nil
+}
+
+// GeneratorNames returns the sorted names of the registered generators.
+func GeneratorNames() []string {
+	var names []string
+
+	generators.Range(func(key, value any) bool {
+		names = append(names, key.(string))
+		return true
+	})
+	slices.Sort(names)
+	return names
+}
+
+// String returns the custom option, or the default value if not exist.
+func (o *Option) String(key, defaultValue string) string {
+	return cmp.Or(o.Options[key], defaultValue)
+}
+
+// Bool returns the custom option as bool, false if not exist or invalid.
+func (o *Option) Bool(key string) bool {
+	v, _ := strconv.ParseBool(o.Options[key])
+	return v
+}
+
+// Prefix returns the custom options with the prefix, the prefix is trimmed.
+// like `tag.json=snakeCase` with prefix `tag.` returns `json=snakeCase`.
+func (o *Option) Prefix(prefix string) map[string]string {
+	m := make(map[string]string)
+	for k, v := range o.Options {
+		if name, ok := strings.CutPrefix(k, prefix); ok && name != "" {
+			m[name] = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+	"github.com/things-go/ens/driver/mysql"
+)
+
+func Test_Registry(t *testing.T) {
+	RegisterGenerator("test", GeneratorFunc(func(sc *ens.Schema, opt *Option) ([]*File, error) {
+		return []*File{{Name: opt.String("name", "test.txt"), Data: []byte(sc.Name)}}, nil
+	}))
+	if names := GeneratorNames(); !slices.Equal(names, []string{Convert, Model, Proto, Rapier, SQL, Template, "test"}) {
+		t.Errorf("unexpected generator names: %v", names)
+	}
+	g, err := LoadGenerator("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := g.Generate(&ens.Schema{Name: "foo"}, &Option{Options: map[string]string{"name": "foo.txt"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name != "foo.txt" || string(files[0].Data) != "foo" {
+		t.Errorf("unexpected files: %v", files)
+	}
+	if _, err = LoadGenerator("unknown"); err == nil {
+		t.Errorf("should be failed with unknown generator")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("should be panic when register twice")
+		}
+	}()
+	RegisterGenerator("test", GeneratorFunc(nil))
+}
+
+func Test_Builtin(t *testing.T) {
+	sc, err := (&mysql.SQL{}).InspectSchema(context.Background(), &driver.InspectOption{
+		Data: "CREATE TABLE `user` (`id` bigint NOT NULL AUTO_INCREMENT, `user_name` varchar(32) NOT NULL, PRIMARY KEY (`id`)) ENGINE=InnoDB;",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name     string
+		options  map[string]string
+		filename string
+		contains string
+	}{
+		{Model, map[string]string{"tag.json": "smallCamelCase", "field.user.id": "uint64"}, "user.go", "Id       uint64 `gorm:\"column:id;not null;autoIncrement:true;primaryKey\" json:\"id,omitempty\"`"},
+		{SQL, map[string]string{"merge": "true"}, "create_table.sql", "CREATE TABLE `user`"},
+		{Proto, map[string]string{"option.go_package": "example.com/api", "disableLock": "true"}, "user.proto", `option go_package = "example.com/api";`},
+		{Rapier, map[string]string{"modelImportPath": "example.com/model"}, "user.rapier.gen.go", `"example.com/model"`},
+		{Convert, map[string]string{"modelImportPath": "example.com/model", "pbImportPath": "example.com/api/pb"}, "user.convert.go", "out.UserName = p.UserName"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := LoadGenerator(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files, err := g.Generate(sc, &Option{ByName: "ormat", Version: "v1.0.0", OutputDir: "./api", Options: tt.options})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(files) != 1 || files[0].Name != tt.filename {
+				t.Fatalf("unexpected files: %v", files)
+			}
+			if !strings.Contains(string(files[0].Data), tt.contains) {
+				t.Errorf("%s missing %q, got:\n%s", tt.filename, tt.contains, files[0].Data)
+			}
+		})
+	}
+	t.Run("convert without import path", func(t *testing.T) {
+		if _, err := genConvert(sc, &Option{ByName: "ormat", Version: "v1.0.0", OutputDir: "./api", Options: map[string]string{"modelImportPath": "example.com/model"}}); err == nil {
+			t.Error("want error of the missing pbImportPath")
+		}
+	})
+	t.Run("proto lock", func(t *testing.T) {
+		files, err := genProto(sc, &Option{ByName: "ormat", Version: "v1.0.0", OutputDir: t.TempDir()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) != 2 || files[1].Name != "proto.lock" || !strings.Contains(string(files[1].Data), `"user_name": 2`) {
+			t.Fatalf("unexpected files: %v", files)
+		}
+	})
+}
+
+func Test_Builtin_CustomField(t *testing.T) {
+	options := map[string]string{
+		"field.user.balance":    "github.com/shopspring/decimal.Decimal",
+		"field.user.created_at": "int64",
+		"disableLock":           "true",
+		"modelImportPath":       "example.com/model",
+		"pbImportPath":          "example.com/api/pb",
+	}
+	for _, tt := range []struct {
+		name     string
+		contains []string
+	}{
+		{Model, []string{`"github.com/shopspring/decimal"`, "Balance   decimal.Decimal `gorm:", "CreatedAt int64 "}},
+		{Proto, []string{"string balance = 2;", "int64 created_at = 3;"}},
+		{Rapier, []string{`rapier.NewDecimal(alias, "balance")`, `rapier.NewInt64(alias, "created_at")`}},
+		{Convert, []string{"out.CreatedAt = p.CreatedAt"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := (&mysql.SQL{}).InspectSchema(context.Background(), &driver.InspectOption{
+				Data: "CREATE TABLE `user` (`id` bigint NOT NULL AUTO_INCREMENT, `balance` decimal(10,2) NOT NULL, `created_at` datetime NOT NULL, PRIMARY KEY (`id`)) ENGINE=InnoDB;",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			g, err := LoadGenerator(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files, err := g.Generate(sc, &Option{ByName: "ormat", Version: "v1.0.0", OutputDir: "./api", Options: options})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(string(files[0].Data), want) {
+					t.Errorf("%s missing %q, got:\n%s", files[0].Name, want, files[0].Data)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/utils"
+)
+
+// Template the template generator name.
+const Template = "template"
+
+const (
+	templateSuffix     = ".tmpl"
+	TemplateModeEntity = "entity" // 每个实体渲染一次
+	TemplateModeSchema = "schema" // 整个schema渲染一次
+)
+
+func init() {
+	RegisterGenerator(Template, GeneratorFunc(genTemplate))
+}
+
+// TemplateData the data of the template.
+type TemplateData struct {
+	ByName      string                // 生成名称
+	Version     string                // 生成版本
+	PackageName string                // 包名
+	Options     map[string]string     // 生成器自定义选项
+	Schema      *ens.Schema           // schema
+	Entity      *ens.EntityDescriptor // 当前实体, schema 模式为 nil
+}
+
+var templateFuncs = template.FuncMap{
+	"pascalCase":     utils.PascalCase,
+	"smallCamelCase": utils.SmallCamelCase,
+	"snakeCase":      utils.SnakeCase,
+	"kebab":          utils.Kebab,
+	"lowTitle":       utils.LowTitle,
+	"styleName":      utils.StyleName,
+	"pkgName":        utils.PkgName,
+	"pkgQualifier":   utils.PkgQualifier,
+	"trimComment":    utils.TrimFieldComment,
+	"goType":         func(t ens.GoType) string { return t.String() },
+	"fieldType": func(field *ens.FieldDescriptor) string {
+		if field.GoPointer && !field.Type.NonPointer {
+			return "*" + field.Type.String()
+		}
+		return field.Type.String()
+	},
+	"protoKind": func(t ens.Type) string {
+		_, name := t.IntoProtoKind()
+		return name
+	},
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"trimSpace": strings.TrimSpace,
+	"replace":   strings.ReplaceAll,
+	"contains":  strings.Contains,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"join":      strings.Join,
+	"split":     strings.Split,
+}
+
+// genTemplate render the user-supplied text/template with TemplateData.
+// options:
+//
+//	template: template file or directory(all *.tmpl files, keep the sub directory), required
+//	mode: entity(default), render per entity, output `{entity}.{template}`; schema, render once, output `{template}`
+//
+// the output file name is the template file name without `.tmpl` suffix, `.go` file is formatted with goimports.
+func genTemplate(sc *ens.Schema, opt *Option) ([]*File, error) {
+	root := opt.String("template", "")
+	if root == "" {
+		return nil, fmt.Errorf("template: option `template` is required")
+	}
+	mode := opt.String("mode", TemplateModeEntity)
+	if mode != TemplateModeEntity && mode != TemplateModeSchema {
+		return nil, fmt.Errorf("template: unsupported mode %q, only support [%s, %s]", mode, TemplateModeEntity, TemplateModeSchema)
+	}
+	filenames, err := templateFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	data := &TemplateData{
+		ByName:      opt.ByName,
+		Version:     opt.Version,
+		PackageName: opt.PackageName,
+		Options:     opt.Options,
+		Schema:      sc,
+		Entity:      nil,
+	}
+	if data.PackageName == "" {
+		data.PackageName = utils.GetPkgName(opt.OutputDir)
+	}
+	files := make([]*File, 0, len(filenames)*len(sc.Entities))
+	names := make([]string, 0, len(filenames))
+	for name := range filenames {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		filename := filenames[name]
+		tpl, err := template.New(filepath.Base(filename)).Funcs(templateFuncs).ParseFiles(filename)
+		if err != nil {
+			return nil, err
+		}
+		dir, base := path.Split(strings.TrimSuffix(name, templateSuffix))
+		if mode == TemplateModeSchema {
+			file, err := renderTemplate(tpl, path.Join(dir, base), data)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+			continue
+		}
+		for _, entity := range sc.Entities {
+			d := *data
+			d.Entity = entity
+			file, err := renderTemplate(tpl, path.Join(dir, entity.Name+"."+base), &d)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// templateFiles returns the template files, key is the relative path(slash separated), value is the file path.
+func templateFiles(root string) (map[string]string, error) {
+	fi, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return map[string]string{filepath.Base(root): root}, nil
+	}
+	filenames := make(map[string]string)
+	err = filepath.WalkDir(root, func(filename string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), templateSuffix) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, filename)
+		if err != nil {
+			return err
+		}
+		filenames[filepath.ToSlash(rel)] = filename
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("template: no %s file found in %s", templateSuffix, root)
+	}
+	return filenames, nil
+}
+
+func renderTemplate(tpl *template.Template, name string, data *TemplateData) (*File, error) {
+	buf := bytes.Buffer{}
+	if err := tpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	content := buf.Bytes()
+	if strings.HasSuffix(name, ".go") {
+		var err error
+
+		content, err = imports.Process("", content, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return &File{Name: name, Data: content}, nil
+}
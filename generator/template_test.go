@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/driver"
+	"github.com/things-go/ens/driver/mysql"
+)
+
+func Test_Template(t *testing.T) {
+	sc, err := (&mysql.SQL{}).InspectSchema(context.Background(), &driver.InspectOption{
+		Data: "CREATE TABLE `user` (`id` bigint NOT NULL AUTO_INCREMENT, `user_name` varchar(32) DEFAULT NULL, PRIMARY KEY (`id`)) ENGINE=InnoDB COMMENT='用户表';" +
+			"CREATE TABLE `post` (`id` bigint NOT NULL, PRIMARY KEY (`id`)) ENGINE=InnoDB;",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := LoadGenerator(Template)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := g.Generate(sc, &Option{ByName: "ormat", OutputDir: "./repo", Options: map[string]string{"template": "./testdata/template"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, file := range files {
+		got[file.Name] = string(file.Data)
+	}
+	if len(got) != 4 {
+		t.Fatalf("unexpected files: %v", got)
+	}
+	for name, wants := range map[string][]string{
+		"user.repo.go": {
+			"package repo\n",
+			"import (\n\t\"context\"\n\t\"database/sql\"\n)",
+			"// UserRepo 用户表\ntype UserRepo struct {",
+			"SELECT COUNT(*) FROM `user`",
+		},
+		"post.repo.go": {"type PostRepo struct {"},
+		"dto/user.dto.go": {
+			"\tId       int64   `json:\"id\"`       // int64\n",
+			"\tUserName *string `json:\"userName\"` // string\n",
+		},
+	} {
+		for _, want := range wants {
+			if !strings.Contains(got[name], want) {
+				t.Errorf("%s missing %q, got:\n%s", name, want, got[name])
+			}
+		}
+	}
+
+	files, err = g.Generate(sc, &Option{Options: map[string]string{"template": "./testdata/tables.md.tmpl", "mode": TemplateModeSchema}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name != "tables.md" || string(files[0].Data) != "- user\n- post\n\n" {
+		t.Errorf("unexpected files: %v", files)
+	}
+
+	for _, options := range []map[string]string{
+		{},
+		{"template": "./testdata/template", "mode": "unknown"},
+		{"template": "./testdata/not_exist"},
+	} {
+		if _, err = g.Generate(&ens.Schema{}, &Option{Options: options}); err == nil {
+			t.Errorf("should be failed with options: %v", options)
+		}
+	}
+}
@@ -5,6 +5,9 @@ go 1.24
 require (
 	ariga.io/atlas v0.32.0
 	github.com/go-sql-driver/mysql v1.9.2
+	github.com/jinzhu/inflection v1.0.0
+	github.com/lib/pq v1.12.3
+	github.com/mattn/go-sqlite3 v1.14.28
 	github.com/pingcap/tidb/parser v0.0.0-20231013125129-93a834a6bf8d
 	github.com/xwb1989/sqlparser v0.0.0-20180606152119-120387863bf2
 	golang.org/x/tools v0.32.0
@@ -24,7 +27,6 @@ require (
 	github.com/google/go-cmp v0.7.0 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/hashicorp/hcl/v2 v2.23.0 // indirect
-	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/mitchellh/go-wordwrap v1.0.1 // indirect
 	github.com/pingcap/errors v0.11.5-0.20221009092201-b66cddb77c32 // indirect
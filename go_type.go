@@ -3,6 +3,7 @@ package ens
 import (
 	"reflect"
 	"slices"
+	"strings"
 
 	"github.com/things-go/ens/rapier"
 	"github.com/things-go/ens/utils"
@@ -32,6 +33,7 @@ var typeNames = [...]string{
 	TypeJSON:    "json.RawMessage",
 	TypeUUID:    "[16]byte",
 	TypeOther:   "other",
+	TypeDate:    "datatypes.Date",
 }
 
 // A Type represents a field type.
@@ -61,6 +63,7 @@ const (
 	TypeJSON
 	TypeUUID
 	TypeOther
+	TypeDate
 	endTypes
 )
 
@@ -134,6 +137,9 @@ func (t Type) IntoProtoKind() (k protoreflect.Kind, n string) {
 	case TypeTime:
 		k = protoreflect.MessageKind
 		n = "google.protobuf.Timestamp"
+	case TypeDate:
+		k = protoreflect.MessageKind
+		n = "google.type.Date"
 	default:
 		k = protoreflect.StringKind
 		n = k.String()
@@ -177,7 +183,7 @@ func (t Type) IntoRapierType() rapier.Type {
 		return rapier.Decimal
 	case TypeBytes:
 		return rapier.Bytes
-	case TypeTime:
+	case TypeTime, TypeDate:
 		return rapier.Time
 	case TypeJSON:
 		return rapier.JSON
@@ -196,6 +202,7 @@ type GoType struct {
 	PkgPath      string // import path. e.g. "", time, database/sql.
 	PkgQualifier string // a package qualifier. e.g. "", time, sql.
 	NonPointer   bool   // pointers or slices, means not need pointer.
+	ElemPkgPath  string // import path of the type argument, e.g. time in sql.Null[time.Time].
 }
 
 func NewGoType(t Type, v any) GoType {
@@ -204,13 +211,34 @@ func NewGoType(t Type, v any) GoType {
 
 func newGoType(t Type, tt reflect.Type) GoType {
 	tv := indirect(tt)
-	return GoType{
+	gt := GoType{
 		Type:         t,
 		Ident:        tt.String(),
 		PkgPath:      tv.PkgPath(),
 		PkgQualifier: utils.PkgQualifier(tv.String()),
 		NonPointer:   slices.Contains([]reflect.Kind{reflect.Slice, reflect.Ptr, reflect.Map}, tt.Kind()),
 	}
+	// the nullable wrapper, like sql.Null[time.Time], datatypes.Null[int64]
+	if name, _, ok := strings.Cut(tv.String(), "["); ok && tv.Kind() == reflect.Struct {
+		gt.PkgQualifier = utils.PkgQualifier(name)
+		if f, ok := tv.FieldByName("V"); ok {
+			gt.ElemPkgPath = indirect(f.Type).PkgPath()
+		}
+	}
+	return gt
+}
+
+// NullGoType returns the go type of the generic nullable wrapper with the type argument t,
+// like sql.Null[int64], datatypes.Null[time.Time].
+func NullGoType(wrapper, t GoType) GoType {
+	return GoType{
+		Type:         t.Type,
+		Ident:        wrapper.Ident + "[" + t.Ident + "]",
+		PkgPath:      wrapper.PkgPath,
+		PkgQualifier: wrapper.PkgQualifier,
+		NonPointer:   false,
+		ElemPkgPath:  t.PkgPath,
+	}
 }
 
 func (t GoType) WithNewType(tp Type) GoType {
@@ -268,7 +296,7 @@ func (t *GoType) IsValid() bool {
 // Comparable reports whether values of this type are comparable.
 func (t *GoType) Comparable() bool {
 	switch t.Type { // nolint: exhaustive
-	case TypeBool, TypeTime, TypeUUID, TypeEnum, TypeString:
+	case TypeBool, TypeTime, TypeDate, TypeUUID, TypeEnum, TypeString:
 		return true
 	case TypeOther:
 		// Always accept custom types as comparable on the database side.
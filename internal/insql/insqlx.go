@@ -2,7 +2,9 @@ package insql
 
 import (
 	"fmt"
+	"path"
 	"reflect"
+	"slices"
 
 	"ariga.io/atlas/sql/schema"
 )
@@ -68,7 +70,7 @@ func IndexEqual(idx1, idx2 *schema.Index) bool {
 
 func FindIndexPartSeq(parts []*schema.IndexPart, col *schema.Column) (int, bool) {
 	for _, p := range parts {
-		if p.C == col || p.C.Name == col.Name {
+		if p.C != nil && (p.C == col || p.C.Name == col.Name) {
 			return p.SeqNo, true
 		}
 	}
@@ -78,7 +80,14 @@ func FindIndexPartSeq(parts []*schema.IndexPart, col *schema.Column) (int, bool)
 func IndexPartColumnNames(parts []*schema.IndexPart) []string {
 	fields := make([]string, 0, len(parts))
 	for _, v := range parts {
-		fields = append(fields, v.C.Name)
+		switch {
+		case v.C != nil:
+			fields = append(fields, v.C.Name)
+		case v.X != nil:
+			if x, ok := v.X.(*schema.RawExpr); ok {
+				fields = append(fields, x.X)
+			}
+		}
 	}
 	return fields
 }
@@ -92,6 +101,20 @@ func FindColumn(columns []*schema.Column, columnName string) (*schema.Column, bo
 	return nil, false
 }
 
+// MatchTable reports whether the table should be inspected, same as atlas inspect options.
+// tables only inspect the tables, exclude the table name glob pattern.
+func MatchTable(name string, tables, exclude []string) bool {
+	if len(tables) > 0 && !slices.Contains(tables, name) {
+		return false
+	}
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, name); ok {
+			return false
+		}
+	}
+	return true
+}
+
 func ColumnNames(columns []*schema.Column) []string {
 	ns := make([]string, 0, len(columns))
 	for _, col := range columns {
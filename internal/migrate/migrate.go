@@ -0,0 +1,134 @@
+// Package migrate read the versioned migration directory, support golang-migrate, atlas and goose style.
+//
+//	golang-migrate: {version}_{title}.up.sql, {version}_{title}.down.sql
+//	atlas:          {version}_{name}.sql
+//	goose:          {version}_{name}.sql, with `-- +goose Up` and `-- +goose Down` annotation.
+package migrate
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// {version}[_{name}][.up|.down].sql, version may be prefixed with `V` (flyway).
+var filenameRegexp = regexp.MustCompile(`^[Vv]?(\d+)(?:[_.-].*?)?(\.up|\.down)?\.sql$`)
+
+// File a migration file.
+type File struct {
+	// Version the version of the migration, leading zeros trimmed.
+	Version string
+	// Name file name.
+	Name string
+	// Path file path.
+	Path string
+}
+
+// ReadDir read the "up" migration files in the directory, ordered by version.
+// the "down" migration files and the files without version will be ignored.
+func ReadDir(dir string) ([]*File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*File, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		version, up := Version(entry.Name())
+		if version == "" || !up {
+			continue
+		}
+		files = append(files, &File{
+			Version: version,
+			Name:    entry.Name(),
+			Path:    filepath.Join(dir, entry.Name()),
+		})
+	}
+	slices.SortFunc(files, func(a, b *File) int { return CompareVersion(a.Version, b.Version) })
+	for i := 1; i < len(files); i++ {
+		if files[i].Version == files[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %s: %s, %s", files[i].Version, files[i-1].Name, files[i].Name)
+		}
+	}
+	return files, nil
+}
+
+// Version returns the version of the migration file name with leading zeros trimmed, empty if no version,
+// up reports whether it is not a "down" migration.
+func Version(name string) (version string, up bool) {
+	matches := filenameRegexp.FindStringSubmatch(name)
+	if matches == nil {
+		return "", false
+	}
+	return cmp.Or(strings.TrimLeft(matches[1], "0"), "0"), matches[2] != ".down"
+}
+
+// CompareVersion compare the versions as number, it may be a timestamp which overflow.
+func CompareVersion(a, b string) int {
+	return cmp.Or(
+		cmp.Compare(len(a), len(b)),
+		strings.Compare(a, b),
+	)
+}
+
+// UpSQL returns the "up" statements of the migration file.
+// goose file only the `-- +goose Up` section, others the whole file.
+func UpSQL(content string) string {
+	if !strings.Contains(content, "+goose") {
+		return content
+	}
+	b := &strings.Builder{}
+	b.Grow(len(content))
+	up := false
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if annotation, ok := strings.CutPrefix(strings.TrimSpace(line), "-- +goose "); ok {
+			switch strings.Fields(annotation)[0] {
+			case "Up":
+				up = true
+			case "Down":
+				up = false
+			}
+			continue
+		}
+		if up {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// ReadUpSQL read all the "up" statements in the directory, ordered by version.
+func ReadUpSQL(dir string) (string, error) {
+	files, err := ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	b := &strings.Builder{}
+	for _, f := range files {
+		content, err := os.ReadFile(f.Path)
+		if err != nil {
+			return "", err
+		}
+		stmts := strings.TrimSpace(UpSQL(string(content)))
+		if stmts == "" {
+			continue
+		}
+		b.WriteString(stmts)
+		if !strings.HasSuffix(stmts, ";") {
+			b.WriteString(";")
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
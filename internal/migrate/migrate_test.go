@@ -0,0 +1,148 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func Test_ReadDir(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"10_user.up.sql":        "",
+		"10_user.down.sql":      "",
+		"9_init.sql":            "",
+		"0001_base.sql":         "",
+		"20240101000000_ts.sql": "",
+		"V2__flyway.sql":        "",
+		"README.md":             "",
+		"init.sql":              "",
+		"abc_1.sql":             "",
+		"1_bad.sql.bak":         "",
+	})
+	if err := os.Mkdir(filepath.Join(dir, "3_dir.sql"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files, err := ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var versions, names []string
+	for _, f := range files {
+		versions = append(versions, f.Version)
+		names = append(names, f.Name)
+	}
+	// version compare as number, by length then string.
+	wantVersions := []string{"1", "2", "9", "10", "20240101000000"}
+	wantNames := []string{"0001_base.sql", "V2__flyway.sql", "9_init.sql", "10_user.up.sql", "20240101000000_ts.sql"}
+	if !slices.Equal(versions, wantVersions) {
+		t.Errorf("versions = %v, want %v", versions, wantVersions)
+	}
+	if !slices.Equal(names, wantNames) {
+		t.Errorf("names = %v, want %v", names, wantNames)
+	}
+	if want := filepath.Join(dir, "0001_base.sql"); files[0].Path != want {
+		t.Errorf("path = %v, want %v", files[0].Path, want)
+	}
+
+	if _, err = ReadDir(filepath.Join(dir, "not_exist")); err == nil {
+		t.Error("want error of the directory not exist")
+	}
+}
+
+func Test_ReadDir_Duplicate(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{"same version", map[string]string{"1_a.sql": "", "1_b.sql": ""}},
+		{"leading zeros", map[string]string{"1_a.sql": "", "001_b.up.sql": ""}},
+		{"zero", map[string]string{"0_a.sql": "", "000_b.sql": ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadDir(writeFiles(t, tt.files)); err == nil {
+				t.Error("want error of the duplicate version")
+			}
+		})
+	}
+	// the down file is not a duplicate of the up file.
+	if _, err := ReadDir(writeFiles(t, map[string]string{"1_a.up.sql": "", "1_a.down.sql": ""})); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_UpSQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			"plain",
+			"CREATE TABLE `a` (`id` bigint);\n",
+			"CREATE TABLE `a` (`id` bigint);\n",
+		},
+		{
+			"goose",
+			"-- +goose Up\n-- +goose StatementBegin\nCREATE TABLE `a` (`id` bigint);\n-- +goose StatementEnd\n" +
+				"-- +goose Down\nDROP TABLE `a`;\n",
+			"CREATE TABLE `a` (`id` bigint);\n",
+		},
+		{
+			"goose down first",
+			"-- +goose Down\nDROP TABLE `a`;\n  -- +goose Up\nCREATE TABLE `a` (`id` bigint);\n",
+			"CREATE TABLE `a` (`id` bigint);\n",
+		},
+		{
+			"goose without up",
+			"-- +goose Down\nDROP TABLE `a`;\n",
+			"",
+		},
+		{
+			"goose without annotation section",
+			"-- +goose NO TRANSACTION\nCREATE TABLE `a` (`id` bigint);\n",
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UpSQL(tt.content); got != tt.want {
+				t.Errorf("UpSQL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ReadUpSQL(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"1_a.up.sql":   "CREATE TABLE `a` (`id` bigint)",
+		"1_a.down.sql": "DROP TABLE `a`;",
+		"2_b.sql":      "-- +goose Down\nDROP TABLE `b`;\n",
+		"3_c.sql":      "-- +goose Up\nCREATE TABLE `c` (`id` bigint);\n-- +goose Down\nDROP TABLE `c`;\n",
+	})
+	got, err := ReadUpSQL(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the goose file without up section is skipped.
+	want := "CREATE TABLE `a` (`id` bigint);\nCREATE TABLE `c` (`id` bigint);\n"
+	if got != want {
+		t.Errorf("ReadUpSQL() = %q, want %q", got, want)
+	}
+
+	if _, err = ReadUpSQL(writeFiles(t, map[string]string{"1_a.sql": "", "01_b.sql": ""})); err == nil {
+		t.Error("want error of the duplicate version")
+	}
+}
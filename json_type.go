@@ -0,0 +1,74 @@
+package ens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// JSONGoType returns the go type of the json column bound to the element type.
+//
+//	map      -> datatypes.JSONMap
+//	[]Item   -> datatypes.JSONSlice[Item]
+//	Settings -> datatypes.JSONType[Settings]
+//
+// the element type must be a builtin type or a type in the same package as the model.
+func JSONGoType(elem string) (GoType, error) {
+	elem = strings.TrimSpace(elem)
+	if elem == "map" || elem == "map[string]any" || elem == "map[string]interface{}" {
+		return DatatypesJSONMapType(), nil
+	}
+	wrapper := "JSONType"
+	if v, ok := strings.CutPrefix(elem, "[]"); ok {
+		wrapper, elem = "JSONSlice", v
+	}
+	if !isJSONElemType(elem) {
+		return GoType{}, fmt.Errorf("invalid json element type %q", elem)
+	}
+	t := datatypesJSONType
+	t.Ident = fmt.Sprintf("%s.%s[%s]", t.PkgQualifier, wrapper, elem)
+	t.NonPointer = wrapper == "JSONSlice" // JSONSlice 同 JSONMap 无需指针, JSONType NULL 需指针
+	return t, nil
+}
+
+// isJSONElemType reports whether the element type is valid, like `Settings`, `*Item`, `int64`, `[]string`.
+func isJSONElemType(elem string) bool {
+	elem = strings.TrimPrefix(strings.TrimLeft(elem, "*"), "[]")
+	if elem == "" {
+		return false
+	}
+	for i, r := range elem {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// OverrideJSONType bind the json columns to the element type, TableName -> ColumnName -> element type,
+// same as the comment annotation `[@jsontype: Settings]`.
+func (s *Schema) OverrideJSONType(elems map[string]map[string]string) error {
+	var errs []error
+	for _, et := range s.Entities {
+		columns := elems[et.Name]
+		if len(columns) == 0 {
+			continue
+		}
+		for _, field := range et.Fields {
+			elem := columns[field.ColumnName]
+			if elem == "" {
+				continue
+			}
+			t, err := JSONGoType(elem)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s.%s: %w", et.Name, field.ColumnName, err))
+				continue
+			}
+			field.Type = t
+			field.GoPointer = field.Nullable && !t.NonPointer
+			field.CustomType = true
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,127 @@
+package ens_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/things-go/ens"
+)
+
+func Test_JSONType(t *testing.T) {
+	sc := parseSQL(t, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `settings` json NOT NULL COMMENT '设置 [@jsontype: Settings]',\n"+
+		"  `profile` json DEFAULT NULL COMMENT '资料 [@jsontype: Profile]',\n"+
+		"  `tags` json DEFAULT NULL COMMENT '标签 [@jsontype: []string]',\n"+
+		"  `extra` json DEFAULT NULL COMMENT '扩展',\n"+
+		"  `raw` json DEFAULT NULL COMMENT '原始',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+	err := sc.OverrideJSONType(map[string]map[string]string{
+		"user": {"extra": "map"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sc.OverrideJSONType(map[string]map[string]string{"user": {"raw": "pkg.Settings"}}); err == nil {
+		t.Error("want error of the invalid element type")
+	}
+
+	g := &ens.CodeGen{
+		Entities:          sc.Entities,
+		ByName:            "ormat",
+		Version:           "v1.0.0",
+		PackageName:       "model",
+		DisableDocComment: true,
+		Option: ens.Option{
+			DisableNullToPoint: true,
+			Tags:               map[string]string{"json": "snakeCase"},
+		},
+	}
+	data, err := g.Gen().FormatSource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(strings.Fields(string(data)), " ")
+	for _, want := range []string{
+		`"gorm.io/datatypes"`,
+		"Settings datatypes.JSONType[Settings] `gorm:",
+		"Profile *datatypes.JSONType[Profile] `gorm:",
+		"Tags datatypes.JSONSlice[string] `gorm:",
+		"Extra datatypes.JSONMap `gorm:",
+		"Raw sql.Null[" + ens.JSONRawMessageType().Ident + "] `gorm:",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, data)
+		}
+	}
+}
+
+func Test_JSONSchemaCodeGen(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "settings.schema.json")
+	err := os.WriteFile(filename, []byte(`{
+  "title": "用户设置",
+  "type": "object",
+  "required": ["theme"],
+  "properties": {
+    "theme": {"type": "string", "description": "主题"},
+    "font_size": {"type": "integer"},
+    "ratio": {"type": ["number", "null"]},
+    "updated_at": {"type": "string", "format": "date-time"},
+    "notify": {
+      "type": "object",
+      "properties": {
+        "email": {"type": "boolean"}
+      }
+    },
+    "shortcuts": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "key": {"type": "string"}
+        }
+      }
+    },
+    "labels": {"type": "array", "items": {"type": "string"}},
+    "extra": {"type": "object"}
+  }
+}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := ens.LoadJSONSchema(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &ens.JSONSchemaCodeGen{
+		Schemas:           map[string]*ens.JSONSchema{"Settings": s},
+		ByName:            "ormat",
+		Version:           "v1.0.0",
+		PackageName:       "model",
+		DisableDocComment: true,
+	}
+	data, err := g.Gen().FormatSource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(strings.Fields(string(data)), " ")
+	for _, want := range []string{
+		`import "time"`,
+		"// Settings 用户设置 type Settings struct { Theme string `json:\"theme\"` // 主题 FontSize int64 `json:\"font_size,omitempty\"`",
+		"Ratio *float64 `json:\"ratio,omitempty\"`",
+		"UpdatedAt time.Time `json:\"updated_at,omitempty\"`",
+		"Notify *SettingsNotify `json:\"notify,omitempty\"`",
+		"Shortcuts []*SettingsShortcut `json:\"shortcuts,omitempty\"`",
+		"Labels []string `json:\"labels,omitempty\"`",
+		"Extra map[string]any `json:\"extra,omitempty\"`",
+		"type SettingsNotify struct { Email bool `json:\"email,omitempty\"` }",
+		"type SettingsShortcut struct { Key string `json:\"key,omitempty\"` }",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, data)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package ens
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+	"golang.org/x/tools/imports"
+)
+
+// JSONSchema the subset of the json schema, used to generate the element struct of the json column.
+type JSONSchema struct {
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        JSONSchemaType     `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Properties  JSONSchemaProperty `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *JSONSchema        `json:"items,omitempty"`
+}
+
+// JSONSchemaType the type of the json schema, string or array, like `"string"`, `["string", "null"]`.
+type JSONSchemaType []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *JSONSchemaType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = JSONSchemaType{s}
+		return nil
+	}
+	var ss []string
+	if err := json.Unmarshal(b, &ss); err != nil {
+		return err
+	}
+	*t = ss
+	return nil
+}
+
+// Is reports whether the type contains the name.
+func (t JSONSchemaType) Is(name string) bool {
+	return slices.Contains(t, name)
+}
+
+// JSONSchemaProperty the properties of the json schema, keep the order in the file.
+type JSONSchemaProperty []*JSONSchemaField
+
+// JSONSchemaField the property of the json schema.
+type JSONSchemaField struct {
+	Name   string
+	Schema *JSONSchema
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *JSONSchemaProperty) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("json schema: properties must be an object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := tok.(string)
+		schema := &JSONSchema{}
+		if err = dec.Decode(schema); err != nil {
+			return fmt.Errorf("json schema: property %s: %w", name, err)
+		}
+		*p = append(*p, &JSONSchemaField{Name: name, Schema: schema})
+	}
+	return nil
+}
+
+// LoadJSONSchema load the json schema file.
+func LoadJSONSchema(filename string) (*JSONSchema, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	s := &JSONSchema{}
+	if err = json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("json schema %s: %w", filename, err)
+	}
+	return s, nil
+}
+
+// JSONSchemaCodeGen generate the element structs of the json columns from the json schemas,
+// the nested object is generated as `{Parent}{Property}` struct.
+type JSONSchemaCodeGen struct {
+	buf               bytes.Buffer
+	Schemas           map[string]*JSONSchema // struct name -> json schema
+	ByName            string
+	Version           string
+	PackageName       string // 包名
+	DisableDocComment bool   // 禁用doc注释
+}
+
+// Bytes returns the CodeBuf's buffer.
+func (g *JSONSchemaCodeGen) Bytes() []byte {
+	return g.buf.Bytes()
+}
+
+// FormatSource return formats and adjusts imports contents of the CodeGen's buffer.
+func (g *JSONSchemaCodeGen) FormatSource() ([]byte, error) {
+	data := g.buf.Bytes()
+	if len(data) == 0 {
+		return data, nil
+	}
+	return imports.Process("", data, &imports.Options{
+		Fragment:   false,
+		AllErrors:  false,
+		Comments:   true,
+		TabIndent:  true,
+		TabWidth:   4,
+		FormatOnly: false,
+	})
+}
+
+// Write appends the contents of p to the buffer,
+func (g *JSONSchemaCodeGen) Write(b []byte) (n int, err error) {
+	return g.buf.Write(b)
+}
+
+// Print formats using the default formats for its operands and writes to the generated output.
+// Spaces are added between operands when neither is a string.
+// It returns the number of bytes written and any write error encountered.
+func (g *JSONSchemaCodeGen) Print(a ...any) (n int, err error) {
+	return fmt.Fprint(&g.buf, a...)
+}
+
+// Printf formats according to a format specifier for its operands and writes to the generated output.
+// It returns the number of bytes written and any write error encountered.
+func (g *JSONSchemaCodeGen) Printf(format string, a ...any) (n int, err error) {
+	return fmt.Fprintf(&g.buf, format, a...)
+}
+
+// Fprintln formats using the default formats to the generated output.
+// Spaces are always added between operands and a newline is appended.
+// It returns the number of bytes written and any write error encountered.
+func (g *JSONSchemaCodeGen) Println(a ...any) (n int, err error) {
+	return fmt.Fprintln(&g.buf, a...)
+}
+
+func (g *JSONSchemaCodeGen) Gen() *JSONSchemaCodeGen {
+	if !g.DisableDocComment {
+		g.Printf("// Code generated by %s. DO NOT EDIT.\n", g.ByName) // nolint: errcheck
+		g.Printf("// version: %s\n", g.Version)                       // nolint: errcheck
+		g.Println()                                                   // nolint: errcheck
+	}
+	g.Printf("package %s\n", g.PackageName) // nolint: errcheck
+	g.Println()                             // nolint: errcheck
+
+	names := make([]string, 0, len(g.Schemas))
+	for name := range g.Schemas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if slices.ContainsFunc(names, func(name string) bool { return g.Schemas[name].usesTime() }) {
+		g.Println(`import "time"`) // nolint: errcheck
+		g.Println()                // nolint: errcheck
+	}
+	for _, name := range names {
+		g.genStruct(name, g.Schemas[name])
+	}
+	return g
+}
+
+func (g *JSONSchemaCodeGen) genStruct(name string, s *JSONSchema) {
+	nested := make(map[string]*JSONSchema)
+	comment := strings.TrimSpace(cmp.Or(s.Description, s.Title))
+	if comment == "" {
+		comment = "json schema"
+	}
+	g.Printf("// %s %s\n", name, strings.ReplaceAll(comment, "\n", "\n// ")) // nolint: errcheck
+	g.Printf("type %s struct {\n", name)                                     // nolint: errcheck
+	for _, p := range s.Properties {
+		fieldName := jsonSchemaFieldName(p.Name)
+		tag := p.Name
+		if !slices.Contains(s.Required, p.Name) {
+			tag += ",omitempty"
+		}
+		goType := p.Schema.goType(name+fieldName, nested)
+		if v := strings.TrimSpace(cmp.Or(p.Schema.Description, p.Schema.Title)); v != "" {
+			g.Printf("%s %s `json:\"%s\"` // %s\n", fieldName, goType, tag, strings.ReplaceAll(v, "\n", " ")) // nolint: errcheck
+		} else {
+			g.Printf("%s %s `json:\"%s\"`\n", fieldName, goType, tag) // nolint: errcheck
+		}
+	}
+	g.Println("}") // nolint: errcheck
+	g.Println()    // nolint: errcheck
+
+	names := make([]string, 0, len(nested))
+	for v := range nested {
+		names = append(names, v)
+	}
+	slices.Sort(names)
+	for _, v := range names {
+		g.genStruct(v, nested[v])
+	}
+}
+
+// goType returns the go type of the schema, the nested object with properties is added to nested with the name.
+func (s *JSONSchema) goType(name string, nested map[string]*JSONSchema) string {
+	pointer := ""
+	if s.Type.Is("null") {
+		pointer = "*"
+	}
+	switch {
+	case s.Type.Is("string"):
+		if s.Format == "date-time" {
+			return pointer + "time.Time"
+		}
+		return pointer + "string"
+	case s.Type.Is("integer"):
+		return pointer + "int64"
+	case s.Type.Is("number"):
+		return pointer + "float64"
+	case s.Type.Is("boolean"):
+		return pointer + "bool"
+	case s.Type.Is("array"):
+		if s.Items == nil {
+			return "[]any"
+		}
+		return "[]" + s.Items.goType(inflection.Singular(name), nested)
+	case s.Type.Is("object") || len(s.Properties) > 0:
+		if len(s.Properties) == 0 {
+			return "map[string]any"
+		}
+		nested[name] = s
+		return "*" + name
+	default:
+		return "any"
+	}
+}
+
+// usesTime reports whether the schema or the nested schema has date-time format string.
+func (s *JSONSchema) usesTime() bool {
+	if s == nil {
+		return false
+	}
+	if s.Type.Is("string") && s.Format == "date-time" {
+		return true
+	}
+	if s.Items.usesTime() {
+		return true
+	}
+	return slices.ContainsFunc(s.Properties, func(p *JSONSchemaField) bool { return p.Schema.usesTime() })
+}
+
+// jsonSchemaFieldName returns the go field name of the property, like `user_name`, `userName` -> `UserName`.
+func jsonSchemaFieldName(name string) string {
+	return enumValueName(name)
+}
@@ -8,6 +8,18 @@ import (
 var reJSONTag = regexp.MustCompile(`^.*\[@(?i:jsontag):\s*([^\[\]]*)\].*`)
 var reAffixJSONTag = regexp.MustCompile(`^.*\[@(affix)\s*\].*`)
 var reProtobufType = regexp.MustCompile(`^.*\[@(?i:pbtype):\s*([^\[\]]*)\].*`)
+var reProtobufNull = regexp.MustCompile(`^.*\[@(?i:pbnull):\s*([^\[\]]*)\].*`)
+var reEnum = regexp.MustCompile(`^.*\[@(?i:enum):\s*([^\[\]]*)\].*`)
+var reGoType = regexp.MustCompile(`^.*\[@(?i:gotype):\s*([^\[\]]*(?:\[\][^\[\]]*)?)\].*`)
+var reJSONType = regexp.MustCompile(`^.*\[@(?i:jsontype):\s*([^\[\]]*(?:\[\][^\[\]]*)?)\].*`)
+var reValidate = regexp.MustCompile(`^.*\[@(?i:validate):\s*([^\[\]]*)\].*`)
+
+// EnumValue 枚举值
+type EnumValue struct {
+	Value   string // 值
+	Name    string // 名称
+	Comment string // 注释
+}
 
 // JsonTag 匹配json标签
 // [@jsontag:id,omitempty]
@@ -35,3 +47,66 @@ func PbType(comment string) string {
 	}
 	return ""
 }
+
+// PbNull 匹配protobuf可空字段的策略
+// [@pbnull: wrapper]
+func PbNull(comment string) string {
+	match := reProtobufNull.FindStringSubmatch(comment)
+	if len(match) == 2 {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
+
+// GoType 匹配自定义go类型, 包含完整导入路径
+// [@gotype: github.com/shopspring/decimal.Decimal]
+func GoType(comment string) string {
+	match := reGoType.FindStringSubmatch(comment)
+	if len(match) == 2 {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
+
+// JSONType 匹配json列绑定的go类型, 如 Settings, []Item, map
+// [@jsontype: Settings]
+func JSONType(comment string) string {
+	match := reJSONType.FindStringSubmatch(comment)
+	if len(match) == 2 {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
+
+// Validate 匹配validator校验规则
+// [@validate: email]
+func Validate(comment string) string {
+	match := reValidate.FindStringSubmatch(comment)
+	if len(match) == 2 {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
+
+// Enum 匹配枚举值, 格式: 值:名称:注释, 名称和注释可省略.
+// [@enum: 1:Male:男,2:Female:女]
+func Enum(comment string) []EnumValue {
+	match := reEnum.FindStringSubmatch(comment)
+	if len(match) != 2 {
+		return nil
+	}
+	values := make([]EnumValue, 0, 8)
+	for _, item := range strings.Split(match[1], ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		vs := strings.SplitN(item, ":", 3)
+		vs = append(vs, "", "")
+		values = append(values, EnumValue{
+			Value:   strings.TrimSpace(vs[0]),
+			Name:    strings.TrimSpace(vs[1]),
+			Comment: strings.TrimSpace(vs[2]),
+		})
+	}
+	return values
+}
@@ -1,6 +1,7 @@
 package matcher
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -125,3 +126,163 @@ func Test_ProtobufType(t *testing.T) {
 		})
 	}
 }
+
+func TestPbNull(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    string
+	}{
+		{
+			"",
+			"11 [@pbnull: wrapper] 11",
+			"wrapper",
+		},
+		{
+			"",
+			"11 [@PbNull:none] 11, [@affix]",
+			"none",
+		},
+		{
+			"",
+			"11 @pbnull[optional] 11",
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PbNull(tt.comment); got != tt.want {
+				t.Errorf("PbNull() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoType(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    string
+	}{
+		{
+			"",
+			"价格 [@gotype: github.com/shopspring/decimal.Decimal]",
+			"github.com/shopspring/decimal.Decimal",
+		},
+		{
+			"",
+			"数据 [@GoType:[]byte] [@affix]",
+			"[]byte",
+		},
+		{
+			"",
+			"11 @gotype[int64] 11",
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GoType(tt.comment); got != tt.want {
+				t.Errorf("GoType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONType(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    string
+	}{
+		{
+			"",
+			"设置 [@jsontype: Settings]",
+			"Settings",
+		},
+		{
+			"",
+			"标签 [@JSONType:[]Tag] [@affix]",
+			"[]Tag",
+		},
+		{
+			"",
+			"11 @jsontype[map] 11",
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JSONType(tt.comment); got != tt.want {
+				t.Errorf("JSONType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    string
+	}{
+		{
+			"",
+			"邮箱 [@validate: email]",
+			"email",
+		},
+		{
+			"",
+			"主页 [@Validate:url,startswith=https] [@affix]",
+			"url,startswith=https",
+		},
+		{
+			"",
+			"11 @validate[email] 11",
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.comment); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnum(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    []EnumValue
+	}{
+		{
+			"",
+			"性别 [@enum: 1:Male:男,2:Female:女]",
+			[]EnumValue{{"1", "Male", "男"}, {"2", "Female", "女"}},
+		},
+		{
+			"",
+			"状态 [@enum:active, inactive:Off]",
+			[]EnumValue{{"active", "", ""}, {"inactive", "Off", ""}},
+		},
+		{
+			"",
+			"状态 [@enum: ]",
+			[]EnumValue{},
+		},
+		{
+			"",
+			"状态 @enum[1:a,2:b]",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Enum(tt.comment); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Enum() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
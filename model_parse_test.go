@@ -3,9 +3,15 @@ package ens_test
 import (
 	"database/sql"
 	"encoding/json"
+	"strings"
+	"testing"
 	"time"
 
+	"gorm.io/datatypes"
 	"gorm.io/plugin/soft_delete"
+
+	"github.com/things-go/ens"
+	_ "github.com/things-go/ens/driver/gomodel"
 )
 
 type Anonymous struct {
@@ -52,3 +58,60 @@ type TestData struct {
 // 		os.WriteFile(des.Name+".rapier.go", bytes, 0644)
 // 	}
 // }
+
+type NullData struct {
+	Age      sql.Null[int8]
+	Score    sql.Null[uint64]
+	Birthday sql.Null[time.Time]
+	Name     datatypes.Null[string]
+	Extra    sql.Null[[]time.Time]
+}
+
+func Test_ParseModel_Null(t *testing.T) {
+	entity, err := ens.ParseModel(NullData{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ens.GoType{
+		{Type: ens.TypeInt8, Ident: "sql.Null[int8]", PkgPath: "database/sql", PkgQualifier: "sql"},
+		{Type: ens.TypeUint64, Ident: "sql.Null[uint64]", PkgPath: "database/sql", PkgQualifier: "sql"},
+		{Type: ens.TypeTime, Ident: "sql.Null[time.Time]", PkgPath: "database/sql", PkgQualifier: "sql", ElemPkgPath: "time"},
+		{Type: ens.TypeString, Ident: "datatypes.Null[string]", PkgPath: "gorm.io/datatypes", PkgQualifier: "datatypes"},
+	}
+	if len(entity.Fields) != len(want) { // unsupported type argument is skipped
+		t.Fatalf("fields count = %d, want %d", len(entity.Fields), len(want))
+	}
+	for i, field := range entity.Fields {
+		if field.Type != want[i] {
+			t.Errorf("%s type = %+v, want %+v", field.GoName, field.Type, want[i])
+		}
+	}
+}
+
+func Test_ParseModel_IntoSQL(t *testing.T) {
+	entity, err := ens.ParseModel(&NullData{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entity.Table == nil {
+		t.Fatal("want the table definition")
+	}
+	got := entity.IntoSQL()
+	if got.Name != "null_data" {
+		t.Errorf("table name = %s, want null_data", got.Name)
+	}
+	for _, want := range []string{
+		"CREATE TABLE `null_data`",
+		"`age` tinyint DEFAULT NULL,",
+		"`score` bigint unsigned DEFAULT NULL,",
+		"`birthday` datetime(3) DEFAULT NULL,",
+		"`name` longtext DEFAULT NULL",
+	} {
+		if !strings.Contains(got.Sql, want) {
+			t.Errorf("definition missing %q, got:\n%s", want, got.Sql)
+		}
+	}
+	if _, err = ens.ParseModel(1); err == nil {
+		t.Error("want error of the non struct")
+	}
+}
@@ -0,0 +1,46 @@
+package ens
+
+import (
+	"fmt"
+)
+
+// the null style of the nullable field when disable null to pointer.
+const (
+	NullStyleSQL       = "sql"       // sql.Nullxx, sql.Null[T] if no sql.Nullxx, the default.
+	NullStyleGeneric   = "generic"   // sql.Null[T], go 1.22+
+	NullStyleDatatypes = "datatypes" // datatypes.Null[T]
+)
+
+// NullWrapperType returns the generic nullable wrapper of the null style,
+// the custom wrapper is the full identifier, like `github.com/guregu/null/v5.Value`,
+// which must be a generic type with one type argument.
+func NullWrapperType(style string) (GoType, error) {
+	switch style {
+	case "", NullStyleSQL, NullStyleGeneric:
+		return SQLNullType(), nil
+	case NullStyleDatatypes:
+		return DatatypesNullType(), nil
+	}
+	t, err := ParseGoType(style, TypeOther)
+	if err != nil {
+		return GoType{}, fmt.Errorf("null style: %w", err)
+	}
+	if t.PkgPath == "" || t.NonPointer {
+		return GoType{}, fmt.Errorf("null style: invalid wrapper %q", style)
+	}
+	return t, nil
+}
+
+// nullValueGoType returns the go type of the nullable field without pointer by the null style.
+func nullValueGoType(t GoType, style string) (GoType, bool) {
+	wrapper, err := NullWrapperType(style)
+	if err != nil {
+		return GoType{}, false
+	}
+	if style == "" || style == NullStyleSQL {
+		if v, ok := getSQLNullValueGoType(t); ok {
+			return v, true
+		}
+	}
+	return NullGoType(wrapper, t), true
+}
@@ -0,0 +1,92 @@
+package ens_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/things-go/ens"
+)
+
+const nullStyleSQL = "CREATE TABLE `metric` (\n" +
+	"  `id` bigint NOT NULL AUTO_INCREMENT,\n" +
+	"  `level` tinyint DEFAULT NULL,\n" +
+	"  `port` int unsigned DEFAULT NULL,\n" +
+	"  `total` bigint unsigned DEFAULT NULL,\n" +
+	"  `ratio` float DEFAULT NULL,\n" +
+	"  `name` varchar(64) DEFAULT NULL,\n" +
+	"  `data` blob DEFAULT NULL,\n" +
+	"  `created_at` datetime DEFAULT NULL,\n" +
+	"  PRIMARY KEY (`id`)\n" +
+	") ENGINE=InnoDB;"
+
+func Test_NullStyle(t *testing.T) {
+	tests := []struct {
+		style string
+		wants []string
+	}{
+		{
+			ens.NullStyleSQL,
+			[]string{
+				"Level sql.NullInt16 `gorm:",
+				"Port sql.NullInt64 `gorm:",
+				"Total sql.Null[uint64] `gorm:",
+				"Ratio sql.NullFloat64 `gorm:",
+				"Name sql.NullString `gorm:",
+				"Data sql.Null[[]uint8] `gorm:",
+				"CreatedAt sql.NullTime `gorm:",
+			},
+		},
+		{
+			ens.NullStyleGeneric,
+			[]string{
+				"Level sql.Null[int8] `gorm:",
+				"Port sql.Null[uint32] `gorm:",
+				"Ratio sql.Null[float32] `gorm:",
+				"CreatedAt sql.Null[time.Time] `gorm:",
+				`"time"`,
+			},
+		},
+		{
+			ens.NullStyleDatatypes,
+			[]string{
+				`"gorm.io/datatypes"`,
+				"Level datatypes.Null[int8] `gorm:",
+				"Name datatypes.Null[string] `gorm:",
+			},
+		},
+		{
+			"github.com/guregu/null/v5.Value",
+			[]string{
+				`"github.com/guregu/null/v5"`,
+				"Total null.Value[uint64] `gorm:",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.style, func(t *testing.T) {
+			g := &ens.CodeGen{
+				Entities:          parseSQL(t, nullStyleSQL).Entities,
+				ByName:            "ormat",
+				Version:           "v1.0.0",
+				PackageName:       "model",
+				DisableDocComment: true,
+				Option: ens.Option{
+					DisableNullToPoint: true,
+					NullStyle:          tt.style,
+					Tags:               map[string]string{"json": "snakeCase"},
+				},
+			}
+			data := string(g.Gen().Bytes())
+			got := strings.Join(strings.Fields(data), " ")
+			for _, want := range tt.wants {
+				if !strings.Contains(got, want) {
+					t.Errorf("want %q in\n%s", want, data)
+				}
+			}
+		})
+	}
+
+	if _, err := ens.NullWrapperType("Value"); err == nil {
+		t.Error("want error of the wrapper without import path")
+	}
+}
@@ -6,10 +6,13 @@ type Option struct {
 	EnableInt          bool              `yaml:"enableInt" json:"enableInt"`                   // 使能int8,uint8,int16,uint16,int32,uint32输出为int,uint
 	EnableBoolInt      bool              `yaml:"enableBoolInt" json:"enableBoolInt"`           // 使能bool输出int
 	DisableNullToPoint bool              `yaml:"disableNullToPoint" json:"disableNullToPoint"` // 禁用字段为null时输出指针类型,将输出为sql.Nullxx
-	EnableForeignKey   bool              `yaml:"enableForeignKey" json:"enableForeignKey"`     // 输出外键
+	NullStyle          string            `yaml:"nullStyle" json:"nullStyle"`                   // 禁用指针时可空字段的类型, sql(默认, sql.Nullxx), generic(sql.Null[T]), datatypes(datatypes.Null[T]) 或自定义泛型包装, 如 github.com/guregu/null/v5.Value
+	EnableForeignKey   bool              `yaml:"enableForeignKey" json:"enableForeignKey"`     // 输出外键关联字段
 	IgnoreOmitempty    bool              `yaml:"ignoreOmitempty" json:"ignoreOmitempty"`       // 忽略tags标签的 omitempty 标签
 	Tags               map[string]string `yaml:"tags" json:"tags"`                             // tags标签列表, 如 json: snakeCase, support smallCamelCase, pascalCase, snakeCase, kebab
 	EscapeName         []string          `yaml:"escapeName" json:"escapeName"`                 // 需要转义的字段
+
+	TypeOption `yaml:",inline"` // 可切换的列类型映射
 }
 
 func defaultOption() *Option {
@@ -17,6 +20,7 @@ func defaultOption() *Option {
 		EnableInt:          false,
 		EnableBoolInt:      false,
 		DisableNullToPoint: false,
+		NullStyle:          NullStyleSQL,
 		EnableForeignKey:   false,
 		IgnoreOmitempty:    false,
 		Tags:               map[string]string{"json": utils.StyleSmallCamelCase},
@@ -3,13 +3,18 @@ package proto
 import (
 	"bytes"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/things-go/ens/utils"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-const googleProtobufTimestamp = "google.protobuf.Timestamp"
+const (
+	googleProtobufTimestamp = "google.protobuf.Timestamp"
+	googleTypeDate          = "google.type.Date"
+)
 
 type CodeGen struct {
 	buf                       bytes.Buffer
@@ -23,6 +28,11 @@ type CodeGen struct {
 	DisableBool               bool              // 禁用bool,使用int32
 	DisableTimestamp          bool              // 禁用google.protobuf.Timestamp,使用int64
 	EnableOpenapiv2Annotation bool              // 启用int64的openapiv2注解
+	EnableService             bool              // 启用 CRUD service 及其请求/响应消息
+	EnableHttpAnnotation      bool              // 启用 service 的 google.api.http 注解, 仅 EnableService 时有效
+	Lock                      *Lock             // 字段编号锁, 为空时按字段顺序编号, 生成时会更新
+	Nullable                  string            // 可空字段的默认策略, optional(默认), wrapper, none, 可由注释 [@pbnull: wrapper] 指定
+	EnableValidate            bool              // 启用 protovalidate(buf.validate.field) 规则
 }
 
 // Bytes returns the CodeBuf's buffer.
@@ -76,38 +86,153 @@ func (g *CodeGen) Gen() *CodeGen {
 	if g.needGoogleProtobufTimestamp(g.Messages) {
 		g.Println(`import "google/protobuf/timestamp.proto";`) // nolint: errcheck
 	}
+	if g.needGoogleTypeDate(g.Messages) {
+		g.Println(`import "google/type/date.proto";`) // nolint: errcheck
+	}
 	if g.needOpenapiv2Annotation(g.Messages) {
 		g.Println(`import "protoc-gen-openapiv2/options/annotations.proto";`) // nolint: errcheck
 	}
+	if g.needProtovalidate(g.Messages) {
+		g.Println(`import "buf/validate/validate.proto";`) // nolint: errcheck
+	}
+	if g.needGoogleProtobufWrappers(g.Messages) {
+		g.Println(`import "google/protobuf/wrappers.proto";`) // nolint: errcheck
+	}
+	if g.EnableService && len(g.Messages) > 0 {
+		if g.EnableHttpAnnotation {
+			g.Println(`import "google/api/annotations.proto";`) // nolint: errcheck
+		}
+		g.Println(`import "google/protobuf/empty.proto";`)      // nolint: errcheck
+		g.Println(`import "google/protobuf/field_mask.proto";`) // nolint: errcheck
+	}
 	g.Println() // nolint: errcheck
 
 	for _, et := range g.Messages {
+		for _, enum := range et.Enums {
+			g.genEnum(enum)
+		}
 		structName := utils.PascalCase(et.Name)
 
 		g.Printf("// %s %s\n", structName, strings.ReplaceAll(strings.TrimSpace(et.Comment), "\n", "\n// ")) // nolint: errcheck
 		g.Printf("message %s {\n", structName)                                                               // nolint: errcheck
+		numbers := g.fieldNumbers(et)
 		for i, m := range et.Fields {
 			if m.Comment != "" {
 				g.Printf("  // %s\n", m.Comment) // nolint: errcheck
 			}
-			typeName, annotations := g.intoTypeNameAndAnnotation(m)
+			cardinality, typeName, annotations := g.intoFieldType(m)
+			annotations = append(annotations, g.validateAnnotations(m, cardinality, typeName)...)
 			fieldName := utils.StyleName(g.Style, m.Name)
 			annotation := ""
 			if len(annotations) > 0 {
 				annotation = fmt.Sprintf(" [%s]", strings.Join(annotations, ", ")) // nolint: errcheck
 			}
-			seq := i + 1
-			if m.Cardinality == protoreflect.Required {
+			seq := numbers[i]
+			if cardinality == protoreflect.Required {
 				g.Printf("  %s %s = %d%s;\n", typeName, fieldName, seq, annotation) // nolint: errcheck
 			} else {
-				g.Printf("  %s %s %s = %d%s;\n", m.Cardinality.String(), typeName, fieldName, seq, annotation) // nolint: errcheck
+				g.Printf("  %s %s %s = %d%s;\n", cardinality.String(), typeName, fieldName, seq, annotation) // nolint: errcheck
 			}
 		}
+		g.genReserved(et)
 		g.Println("}") // nolint: errcheck
+		if g.EnableService {
+			g.Println() // nolint: errcheck
+			g.genService(et)
+		}
 	}
 	return g
 }
 
+// fieldNumbers returns the field numbers of the message, assigned by the lock if exist, otherwise by position.
+func (g *CodeGen) fieldNumbers(msg *Message) []int32 {
+	if g.Lock != nil {
+		return g.Lock.assign(msg)
+	}
+	numbers := make([]int32, 0, len(msg.Fields))
+	for i := range msg.Fields {
+		numbers = append(numbers, int32(i+1))
+	}
+	return numbers
+}
+
+// genReserved generate the reserved numbers and names of the dropped columns.
+func (g *CodeGen) genReserved(msg *Message) {
+	if g.Lock == nil || g.Lock.Messages[msg.TableName] == nil {
+		return
+	}
+	ml := g.Lock.Messages[msg.TableName]
+	if len(ml.ReservedNumbers) > 0 {
+		vs := make([]string, 0, len(ml.ReservedNumbers))
+		for _, n := range ml.ReservedNumbers {
+			vs = append(vs, strconv.Itoa(int(n)))
+		}
+		g.Printf("  reserved %s;\n", strings.Join(vs, ", ")) // nolint: errcheck
+	}
+	if len(ml.ReservedNames) > 0 {
+		vs := make([]string, 0, len(ml.ReservedNames))
+		for _, name := range ml.ReservedNames {
+			vs = append(vs, strconv.Quote(utils.StyleName(g.Style, name)))
+		}
+		g.Printf("  reserved %s;\n", strings.Join(vs, ", ")) // nolint: errcheck
+	}
+}
+
+func (g *CodeGen) genEnum(enum *Enum) {
+	if enum.Comment != "" {
+		g.Printf("// %s %s\n", enum.Name, strings.ReplaceAll(strings.TrimSpace(enum.Comment), "\n", "\n// ")) // nolint: errcheck
+	}
+	g.Printf("enum %s {\n", enum.Name) // nolint: errcheck
+	genValue := func(v *EnumValue) {
+		if v.Comment != "" {
+			g.Printf("  // %s\n", v.Comment) // nolint: errcheck
+		}
+		g.Printf("  %s = %d;\n", v.Name, v.Number) // nolint: errcheck
+	}
+	// proto3 第一个枚举值必须为零值
+	idx := slices.IndexFunc(enum.Values, func(v *EnumValue) bool { return v.Number == 0 })
+	if idx == -1 {
+		g.Printf("  %s_UNSPECIFIED = 0;\n", strings.ToUpper(utils.SnakeCase(enum.Name))) // nolint: errcheck
+	} else {
+		genValue(enum.Values[idx])
+	}
+	for i, v := range enum.Values {
+		if i != idx {
+			genValue(v)
+		}
+	}
+	g.Println("}") // nolint: errcheck
+	g.Println()    // nolint: errcheck
+}
+
+// intoFieldType returns the cardinality, type name and annotations of the field with the nullable strategy.
+func (g *CodeGen) intoFieldType(field *MessageField) (protoreflect.Cardinality, string, []string) {
+	typeName, annotations := g.intoTypeNameAndAnnotation(field)
+	switch NullableOf(field, g.Nullable) {
+	case NullableNone:
+		return protoreflect.Required, typeName, annotations
+	case NullableWrapper:
+		if wrapper, ok := WrapperType(typeName); ok {
+			return protoreflect.Required, wrapper, nil
+		}
+	}
+	return field.Cardinality, typeName, annotations
+}
+
+func (g *CodeGen) needGoogleProtobufWrappers(messages []*Message) bool {
+	for _, msg := range messages {
+		for _, v := range msg.Fields {
+			_, typeName, _ := g.intoFieldType(v)
+			for _, wrapper := range wrapperTypes {
+				if typeName == wrapper {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func (g *CodeGen) intoTypeNameAndAnnotation(field *MessageField) (string, []string) {
 	annotations := make([]string, 0, 8)
 	switch {
@@ -122,6 +247,9 @@ func (g *CodeGen) intoTypeNameAndAnnotation(field *MessageField) (string, []stri
 		} else {
 			return field.TypeName, annotations
 		}
+	case field.Type == protoreflect.EnumKind,
+		field.Type == protoreflect.MessageKind && field.TypeName != "":
+		return field.TypeName, annotations
 	case (field.Type == protoreflect.Int64Kind || field.Type == protoreflect.Uint64Kind) && g.EnableOpenapiv2Annotation:
 		annotations = append(annotations, `(grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field) = { type: [ INTEGER ] }`)
 		fallthrough
@@ -155,3 +283,14 @@ func (g *CodeGen) needGoogleProtobufTimestamp(messages []*Message) bool {
 	}
 	return false
 }
+
+func (g *CodeGen) needGoogleTypeDate(messages []*Message) bool {
+	for _, msg := range messages {
+		for _, v := range msg.Fields {
+			if v.Type == protoreflect.MessageKind && v.TypeName == googleTypeDate {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package proto
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// Lock the field numbers of the messages, keep the field numbers stable between generations.
+// new column get a fresh number, dropped column become reserved number and name.
+type Lock struct {
+	Messages map[string]*MessageLock `json:"messages"` // table name -> message lock
+}
+
+// MessageLock the field numbers of a message.
+type MessageLock struct {
+	Fields          map[string]int32 `json:"fields"`                    // column name -> field number
+	ReservedNumbers []int32          `json:"reservedNumbers,omitempty"` // 已删除列的编号
+	ReservedNames   []string         `json:"reservedNames,omitempty"`   // 已删除的列名
+}
+
+// NewLock returns an empty lock.
+func NewLock() *Lock {
+	return &Lock{Messages: make(map[string]*MessageLock)}
+}
+
+// LoadLock load the lock file, returns an empty lock if the file not exist.
+func LoadLock(filename string) (*Lock, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return NewLock(), nil
+		}
+		return nil, err
+	}
+	l := NewLock()
+	if err = json.Unmarshal(data, l); err != nil {
+		return nil, err
+	}
+	if l.Messages == nil {
+		l.Messages = make(map[string]*MessageLock)
+	}
+	return l, nil
+}
+
+// Bytes returns the json encoding of the lock.
+func (l *Lock) Bytes() ([]byte, error) {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// assign the field numbers of the message, and update the lock.
+// returns the field numbers in the order of msg.Fields.
+func (l *Lock) assign(msg *Message) []int32 {
+	ml, ok := l.Messages[msg.TableName]
+	if !ok || ml == nil {
+		ml = &MessageLock{}
+		l.Messages[msg.TableName] = ml
+	}
+	if ml.Fields == nil {
+		ml.Fields = make(map[string]int32)
+	}
+	maxNumber := int32(0)
+	for _, n := range ml.Fields {
+		maxNumber = max(maxNumber, n)
+	}
+	for _, n := range ml.ReservedNumbers {
+		maxNumber = max(maxNumber, n)
+	}
+	//* dropped column
+	columns := make(map[string]struct{}, len(msg.Fields))
+	for _, field := range msg.Fields {
+		columns[field.ColumnName] = struct{}{}
+	}
+	for column, n := range ml.Fields {
+		if _, ok := columns[column]; !ok {
+			delete(ml.Fields, column)
+			ml.ReservedNumbers = append(ml.ReservedNumbers, n)
+			ml.ReservedNames = append(ml.ReservedNames, column)
+		}
+	}
+	//* new column
+	numbers := make([]int32, 0, len(msg.Fields))
+	for _, field := range msg.Fields {
+		n, ok := ml.Fields[field.ColumnName]
+		if !ok {
+			maxNumber++
+			n = maxNumber
+			ml.Fields[field.ColumnName] = n
+			// 重新添加的列, 名称不再保留, 但旧编号仍保留
+			ml.ReservedNames = slices.DeleteFunc(ml.ReservedNames, func(v string) bool { return v == field.ColumnName })
+		}
+		numbers = append(numbers, n)
+	}
+	slices.Sort(ml.ReservedNumbers)
+	ml.ReservedNames = slices.Compact(slices.Sorted(slices.Values(ml.ReservedNames)))
+	return numbers
+}
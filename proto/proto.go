@@ -1,14 +1,81 @@
 package proto
 
-import "google.golang.org/protobuf/reflect/protoreflect"
+import (
+	"cmp"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// nullable strategy of the optional field.
+const (
+	NullableOptional = "optional" // proto3 optional, default
+	NullableWrapper  = "wrapper"  // google.protobuf.*Value, fallback to optional if the type has no wrapper
+	NullableNone     = "none"     // plain non-optional
+)
+
+// wrapperTypes scalar type to google.protobuf.*Value wrapper type.
+var wrapperTypes = map[string]string{
+	"bool":   "google.protobuf.BoolValue",
+	"int32":  "google.protobuf.Int32Value",
+	"int64":  "google.protobuf.Int64Value",
+	"uint32": "google.protobuf.UInt32Value",
+	"uint64": "google.protobuf.UInt64Value",
+	"float":  "google.protobuf.FloatValue",
+	"double": "google.protobuf.DoubleValue",
+	"string": "google.protobuf.StringValue",
+	"bytes":  "google.protobuf.BytesValue",
+}
+
+// WrapperType returns the google.protobuf.*Value wrapper type of the scalar type, like int64 -> google.protobuf.Int64Value.
+func WrapperType(typeName string) (string, bool) {
+	v, ok := wrapperTypes[typeName]
+	return v, ok
+}
+
+// NullableOf returns the nullable strategy of the field, the field annotation `[@pbnull: wrapper]` is preferred,
+// then the default strategy, returns empty if the field is not optional.
+func NullableOf(field *MessageField, defaultNullable string) string {
+	if field.Cardinality != protoreflect.Optional {
+		return ""
+	}
+	switch v := cmp.Or(field.Nullable, defaultNullable); v {
+	case NullableWrapper, NullableNone:
+		return v
+	default:
+		return NullableOptional
+	}
+}
 
 type MessageField struct {
 	Cardinality protoreflect.Cardinality // optional, required, or repeated
 	Type        protoreflect.Kind        // 类型
-	TypeName    string                   // 类型名称, 如果Type=protoreflect.MessageKind时
+	TypeName    string                   // 类型名称, 如果Type=protoreflect.MessageKind或protoreflect.EnumKind时
 	Name        string                   // 名称, snake or small camel case
 	ColumnName  string                   // 列名, snake case
 	Comment     string                   // 注释
+	Nullable    string                   // 可空策略, optional, wrapper, none, 为空时使用默认策略
+	Constraint  *Constraint              // 字段约束, 用于生成 protovalidate 规则
+}
+
+// Constraint the constraint of the field derived from the column metadata.
+type Constraint struct {
+	Required bool     // NOT NULL 且没有默认值
+	MaxLen   int64    // 字符串或字节的最大长度, 0 表示不限制
+	Min      *int64   // 整型最小值, 如 tinyint -128
+	Max      *int64   // 整型最大值, 如 tinyint unsigned 255
+	In       []string // 枚举值, 字段为protobuf枚举时使用 defined_only
+}
+
+type EnumValue struct {
+	Name    string // 名称, upper snake case, 带枚举名前缀
+	Number  int32  // 值
+	Comment string // 注释
+}
+
+type Enum struct {
+	Name    string       // 名称, camel case
+	Comment string       // 注释
+	Values  []*EnumValue // 枚举值, 不包含零值 _UNSPECIFIED
 }
 
 type Message struct {
@@ -16,6 +83,10 @@ type Message struct {
 	TableName string          // 表名, snake name
 	Comment   string          // 注释
 	Fields    []*MessageField // 字段
+	Enums     []*Enum         // 字段引用的枚举
+	// for service
+	PrimaryKeys  []string // 主键列名
+	IndexColumns []string // 索引列名(不包含主键), List 的过滤条件
 }
 
 type Schema struct {
@@ -0,0 +1,134 @@
+package proto
+
+import (
+	"strings"
+
+	"github.com/jinzhu/inflection"
+
+	"github.com/things-go/ens/utils"
+)
+
+// genService generate the CRUD service and the request/response messages of the message, like:
+//
+//	service UserService {
+//	  rpc CreateUser(CreateUserRequest) returns (User);
+//	  rpc GetUser(GetUserRequest) returns (User);
+//	  rpc UpdateUser(UpdateUserRequest) returns (User);
+//	  rpc DeleteUser(DeleteUserRequest) returns (google.protobuf.Empty);
+//	  rpc ListUsers(ListUsersRequest) returns (ListUsersResponse);
+//	}
+//
+// Get, Update and Delete are generated only if the message has primary keys.
+func (g *CodeGen) genService(msg *Message) {
+	name := utils.PascalCase(msg.Name)
+	plural := inflection.Plural(name)
+	resourceName := utils.StyleName(g.Style, utils.SnakeCase(msg.Name))
+	pluralName := utils.StyleName(g.Style, utils.SnakeCase(plural))
+	path := "/v1/" + utils.Kebab(plural)
+
+	pkFields := make([]*MessageField, 0, len(msg.PrimaryKeys))
+	for _, column := range msg.PrimaryKeys {
+		if field := findField(msg, column); field != nil {
+			pkFields = append(pkFields, field)
+		}
+	}
+	pkPath, updatePkPath := "", ""
+	for _, field := range pkFields {
+		fieldName := utils.StyleName(g.Style, field.Name)
+		pkPath += "/{" + fieldName + "}"
+		updatePkPath += "/{" + resourceName + "." + fieldName + "}"
+	}
+	hasPk := len(pkFields) > 0
+
+	//* request, response
+	g.Printf("// Create%sRequest create %s request\n", name, name) // nolint: errcheck
+	g.Printf("message Create%sRequest {\n", name)                  // nolint: errcheck
+	g.Printf("  %s %s = 1;\n", name, resourceName)                 // nolint: errcheck
+	g.Println("}")                                                 // nolint: errcheck
+	g.Println()                                                    // nolint: errcheck
+	if hasPk {
+		for _, method := range []string{"Get", "Delete"} {
+			g.Printf("// %s%sRequest %s %s request\n", method, name, strings.ToLower(method), name) // nolint: errcheck
+			g.Printf("message %s%sRequest {\n", method, name)                                       // nolint: errcheck
+			for i, field := range pkFields {
+				typeName, _ := g.intoTypeNameAndAnnotation(field)
+				g.Printf("  %s %s = %d;\n", typeName, utils.StyleName(g.Style, field.Name), i+1) // nolint: errcheck
+			}
+			g.Println("}") // nolint: errcheck
+			g.Println()    // nolint: errcheck
+		}
+		g.Printf("// Update%sRequest update %s request\n", name, name)                             // nolint: errcheck
+		g.Printf("message Update%sRequest {\n", name)                                              // nolint: errcheck
+		g.Printf("  %s %s = 1;\n", name, resourceName)                                             // nolint: errcheck
+		g.Println("  // the fields to update, update all fields if empty")                         // nolint: errcheck
+		g.Printf("  google.protobuf.FieldMask %s = 2;\n", utils.StyleName(g.Style, "update_mask")) // nolint: errcheck
+		g.Println("}")                                                                             // nolint: errcheck
+		g.Println()                                                                                // nolint: errcheck
+	}
+	g.Printf("// List%sRequest list %s request\n", plural, name)                      // nolint: errcheck
+	g.Printf("message List%sRequest {\n", plural)                                     // nolint: errcheck
+	g.Println("  // the maximum number of items to return")                           // nolint: errcheck
+	g.Printf("  int32 %s = 1;\n", utils.StyleName(g.Style, "page_size"))              // nolint: errcheck
+	g.Println("  // the next_page_token value returned from a previous List request") // nolint: errcheck
+	g.Printf("  string %s = 2;\n", utils.StyleName(g.Style, "page_token"))            // nolint: errcheck
+	g.Println("  // the order of the items, like `id desc, created_at`")              // nolint: errcheck
+	g.Printf("  string %s = 3;\n", utils.StyleName(g.Style, "order_by"))              // nolint: errcheck
+	seq := 4
+	for _, column := range msg.IndexColumns {
+		field := findField(msg, column)
+		if field == nil {
+			continue
+		}
+		typeName, _ := g.intoTypeNameAndAnnotation(field)
+		if field.Comment != "" {
+			g.Printf("  // %s\n", field.Comment) // nolint: errcheck
+		}
+		g.Printf("  optional %s %s = %d;\n", typeName, utils.StyleName(g.Style, field.Name), seq) // nolint: errcheck
+		seq++
+	}
+	g.Println("}")                                                                  // nolint: errcheck
+	g.Println()                                                                     // nolint: errcheck
+	g.Printf("// List%sResponse list %s response\n", plural, name)                  // nolint: errcheck
+	g.Printf("message List%sResponse {\n", plural)                                  // nolint: errcheck
+	g.Printf("  repeated %s %s = 1;\n", name, pluralName)                           // nolint: errcheck
+	g.Println("  // a token to retrieve the next page, empty if there are no more") // nolint: errcheck
+	g.Printf("  string %s = 2;\n", utils.StyleName(g.Style, "next_page_token"))     // nolint: errcheck
+	g.Println("}")                                                                  // nolint: errcheck
+	g.Println()                                                                     // nolint: errcheck
+
+	//* service
+	g.Printf("// %sService %s service\n", name, name) // nolint: errcheck
+	g.Printf("service %sService {\n", name)           // nolint: errcheck
+	g.genRpc("Create"+name, "Create"+name+"Request", name, "post", path, resourceName)
+	if hasPk {
+		g.genRpc("Get"+name, "Get"+name+"Request", name, "get", path+pkPath, "")
+		g.genRpc("Update"+name, "Update"+name+"Request", name, "patch", path+updatePkPath, resourceName)
+		g.genRpc("Delete"+name, "Delete"+name+"Request", "google.protobuf.Empty", "delete", path+pkPath, "")
+	}
+	g.genRpc("List"+plural, "List"+plural+"Request", "List"+plural+"Response", "get", path, "")
+	g.Println("}") // nolint: errcheck
+}
+
+func (g *CodeGen) genRpc(method, request, response, httpMethod, path, body string) {
+	if !g.EnableHttpAnnotation {
+		g.Printf("  rpc %s(%s) returns (%s);\n", method, request, response) // nolint: errcheck
+		return
+	}
+	g.Printf("  rpc %s(%s) returns (%s) {\n", method, request, response) // nolint: errcheck
+	g.Println("    option (google.api.http) = {")                        // nolint: errcheck
+	g.Printf("      %s: \"%s\"\n", httpMethod, path)                     // nolint: errcheck
+	if body != "" {
+		g.Printf("      body: \"%s\"\n", body) // nolint: errcheck
+	}
+	g.Println("    };") // nolint: errcheck
+	g.Println("  }")    // nolint: errcheck
+}
+
+func findField(msg *Message, column string) *MessageField {
+	for _, field := range msg.Fields {
+		if field.ColumnName == column {
+			return field
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package proto
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const protovalidateField = "(buf.validate.field)"
+
+// validateRuleTypes the protovalidate rule type of the scalar or wrapper type.
+var validateRuleTypes = map[string]string{
+	"string":                      "string",
+	"bytes":                       "bytes",
+	"int32":                       "int32",
+	"int64":                       "int64",
+	"uint32":                      "uint32",
+	"uint64":                      "uint64",
+	"google.protobuf.StringValue": "string",
+	"google.protobuf.BytesValue":  "bytes",
+	"google.protobuf.Int32Value":  "int32",
+	"google.protobuf.Int64Value":  "int64",
+	"google.protobuf.UInt32Value": "uint32",
+	"google.protobuf.UInt64Value": "uint64",
+}
+
+// validateIntRanges the value range of the integer rule type, the bound not narrower than it is omitted.
+// uint64 limit to math.MaxInt64 as the constraint is int64.
+var validateIntRanges = map[string][2]int64{
+	"int32":  {math.MinInt32, math.MaxInt32},
+	"int64":  {math.MinInt64, math.MaxInt64},
+	"uint32": {0, math.MaxUint32},
+	"uint64": {0, math.MaxInt64},
+}
+
+// validateAnnotations returns the protovalidate rules of the field, typeName is the final type name of the field.
+//
+//	(buf.validate.field).required = true
+//	(buf.validate.field).string = {max_len: 64}
+//	(buf.validate.field).uint32 = {lte: 255}
+//	(buf.validate.field).enum = {defined_only: true}
+func (g *CodeGen) validateAnnotations(field *MessageField, cardinality protoreflect.Cardinality, typeName string) []string {
+	c := field.Constraint
+	if !g.EnableValidate || c == nil {
+		return nil
+	}
+	annotations := make([]string, 0, 2)
+	// bool 的 required 表示必须为 true, 忽略
+	if c.Required && cardinality == protoreflect.Required && field.Type != protoreflect.BoolKind {
+		annotations = append(annotations, protovalidateField+".required = true")
+	}
+	if field.Type == protoreflect.EnumKind {
+		return append(annotations, protovalidateField+".enum = {defined_only: true}")
+	}
+	ruleType, ok := validateRuleTypes[typeName]
+	if !ok {
+		return annotations
+	}
+	rules := make([]string, 0, 2)
+	switch ruleType {
+	case "string", "bytes":
+		if c.MaxLen > 0 {
+			rules = append(rules, "max_len: "+strconv.FormatInt(c.MaxLen, 10))
+		}
+		if ruleType == "string" && len(c.In) > 0 {
+			values := make([]string, 0, len(c.In))
+			for _, v := range c.In {
+				values = append(values, strconv.Quote(v))
+			}
+			rules = append(rules, fmt.Sprintf("in: [%s]", strings.Join(values, ", ")))
+		}
+	case "int32", "int64", "uint32", "uint64":
+		r := validateIntRanges[ruleType]
+		if c.Min != nil && *c.Min > r[0] {
+			rules = append(rules, "gte: "+strconv.FormatInt(*c.Min, 10))
+		}
+		if c.Max != nil && *c.Max < r[1] {
+			rules = append(rules, "lte: "+strconv.FormatInt(*c.Max, 10))
+		}
+	}
+	if len(rules) > 0 {
+		annotations = append(annotations, fmt.Sprintf("%s.%s = {%s}", protovalidateField, ruleType, strings.Join(rules, ", ")))
+	}
+	return annotations
+}
+
+func (g *CodeGen) needProtovalidate(messages []*Message) bool {
+	for _, msg := range messages {
+		for _, v := range msg.Fields {
+			cardinality, typeName, _ := g.intoFieldType(v)
+			if len(g.validateAnnotations(v, cardinality, typeName)) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
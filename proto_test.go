@@ -0,0 +1,234 @@
+package ens_test
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/things-go/ens/proto"
+)
+
+func Test_ProtoService(t *testing.T) {
+	sc := parseSQL(t, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `name` varchar(64) NOT NULL COMMENT '名称',\n"+
+		"  `status` tinyint NOT NULL DEFAULT '0' COMMENT '状态 [@enum: 1:Active,2:Inactive]',\n"+
+		"  `remark` varchar(255) NOT NULL,\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  KEY `idx_name_status` (`name`,`status`)\n"+
+		") ENGINE=InnoDB;")
+
+	g := &proto.CodeGen{
+		Messages:             sc.IntoProto().Entities,
+		ByName:               "ormat",
+		Version:              "v1.0.0",
+		PackageName:          "api",
+		DisableDocComment:    true,
+		EnableService:        true,
+		EnableHttpAnnotation: true,
+	}
+	got := strings.Join(strings.Fields(string(g.Gen().Bytes())), " ")
+	for _, want := range []string{
+		`import "google/api/annotations.proto";`,
+		`import "google/protobuf/field_mask.proto";`,
+		"message CreateUserRequest { User user = 1; }",
+		"message GetUserRequest { int64 id = 1; }",
+		"message DeleteUserRequest { int64 id = 1; }",
+		"google.protobuf.FieldMask update_mask = 2; }",
+		"int32 page_size = 1;",
+		"string page_token = 2;",
+		"string order_by = 3;",
+		"// 名称 optional string name = 4; // 状态 [@enum: 1:Active,2:Inactive] optional UserStatus status = 5; }",
+		"message ListUsersResponse { repeated User users = 1;",
+		"service UserService {",
+		`rpc CreateUser(CreateUserRequest) returns (User) { option (google.api.http) = { post: "/v1/users" body: "user" }; }`,
+		`rpc GetUser(GetUserRequest) returns (User) { option (google.api.http) = { get: "/v1/users/{id}" }; }`,
+		`rpc UpdateUser(UpdateUserRequest) returns (User) { option (google.api.http) = { patch: "/v1/users/{user.id}" body: "user" }; }`,
+		`rpc DeleteUser(DeleteUserRequest) returns (google.protobuf.Empty) { option (google.api.http) = { delete: "/v1/users/{id}" }; }`,
+		`rpc ListUsers(ListUsersRequest) returns (ListUsersResponse) { option (google.api.http) = { get: "/v1/users" }; }`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, g.Bytes())
+		}
+	}
+	if strings.Contains(got, "optional string remark") || strings.Contains(got, "optional int64 id") {
+		t.Errorf("only the indexed columns are the filters\n%s", g.Bytes())
+	}
+}
+
+func Test_ProtoLock(t *testing.T) {
+	gen := func(lock *proto.Lock, data string) string {
+		g := &proto.CodeGen{
+			Messages:          parseSQL(t, data).IntoProto().Entities,
+			ByName:            "ormat",
+			Version:           "v1.0.0",
+			PackageName:       "api",
+			DisableDocComment: true,
+			Lock:              lock,
+		}
+		return strings.Join(strings.Fields(string(g.Gen().Bytes())), " ")
+	}
+	lock := proto.NewLock()
+	got := gen(lock, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `name` varchar(64) NOT NULL,\n"+
+		"  `age` int NOT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+	want := "message User { int64 id = 1; string name = 2; int32 age = 3; }"
+	if !strings.Contains(got, want) {
+		t.Fatalf("want %q in\n%s", want, got)
+	}
+
+	// 序列化后重新加载, 删除 name, 新增 email
+	data, err := lock.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := t.TempDir() + "/proto.lock"
+	if err = os.WriteFile(filename, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	lock, err = proto.LoadLock(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = gen(lock, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `email` varchar(64) NOT NULL,\n"+
+		"  `age` int NOT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+	want = `message User { int64 id = 1; string email = 4; int32 age = 3; reserved 2; reserved "name"; }`
+	if !strings.Contains(got, want) {
+		t.Fatalf("want %q in\n%s", want, got)
+	}
+
+	// 重新添加 name, 使用新的编号, 旧编号仍保留
+	got = gen(lock, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `name` varchar(64) NOT NULL,\n"+
+		"  `email` varchar(64) NOT NULL,\n"+
+		"  `age` int NOT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+	want = `message User { int64 id = 1; string name = 5; string email = 4; int32 age = 3; reserved 2; }`
+	if !strings.Contains(got, want) {
+		t.Fatalf("want %q in\n%s", want, got)
+	}
+}
+
+func Test_ProtoNullable(t *testing.T) {
+	sc := parseSQL(t, "CREATE TABLE `user` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `age` bigint DEFAULT NULL,\n"+
+		"  `name` varchar(64) DEFAULT NULL COMMENT '名称 [@pbnull: optional]',\n"+
+		"  `remark` varchar(64) DEFAULT NULL COMMENT '备注 [@pbnull: none]',\n"+
+		"  `birthday` datetime DEFAULT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+	for _, tt := range []struct {
+		nullable string
+		wants    []string
+	}{
+		{
+			"",
+			[]string{
+				"int64 id = 1;",
+				"optional int64 age = 2;",
+				"optional string name = 3;",
+				"string remark = 4;",
+				"optional google.protobuf.Timestamp birthday = 5;",
+			},
+		},
+		{
+			proto.NullableWrapper,
+			[]string{
+				`import "google/protobuf/wrappers.proto";`,
+				"int64 id = 1;",
+				"google.protobuf.Int64Value age = 2;",
+				"optional string name = 3;",
+				"string remark = 4;",
+				"optional google.protobuf.Timestamp birthday = 5;",
+			},
+		},
+		{
+			proto.NullableNone,
+			[]string{
+				"int64 age = 2;",
+				"optional string name = 3;",
+				"string remark = 4;",
+				"google.protobuf.Timestamp birthday = 5;",
+			},
+		},
+	} {
+		g := &proto.CodeGen{
+			Messages:          sc.IntoProto().Entities,
+			ByName:            "ormat",
+			Version:           "v1.0.0",
+			PackageName:       "api",
+			DisableDocComment: true,
+			Nullable:          tt.nullable,
+		}
+		got := string(g.Gen().Bytes())
+		lines := strings.Split(got, "\n")
+		for i := range lines {
+			lines[i] = strings.TrimSpace(lines[i])
+		}
+		for _, want := range tt.wants {
+			if !slices.Contains(lines, want) {
+				t.Errorf("%s: want line %q in\n%s", tt.nullable, want, got)
+			}
+		}
+	}
+}
+
+func Test_ProtoValidate(t *testing.T) {
+	sc := parseSQL(t, "CREATE TABLE `user` (\n"+
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `name` varchar(64) NOT NULL,\n"+
+		"  `nickname` varchar(32) DEFAULT NULL,\n"+
+		"  `age` tinyint unsigned NOT NULL DEFAULT '0',\n"+
+		"  `level` smallint NOT NULL DEFAULT '0',\n"+
+		"  `enabled` tinyint(1) NOT NULL,\n"+
+		"  `status` enum('active','inactive') NOT NULL DEFAULT 'active',\n"+
+		"  `sex` tinyint NOT NULL COMMENT '性别 [@enum: 1:Male,2:Female]',\n"+
+		"  `port` int unsigned NOT NULL DEFAULT '0',\n"+
+		"  `score` int NOT NULL DEFAULT '0',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+	msgs := sc.IntoProto().Entities
+	// mysql 枚举不生成protobuf枚举时, 使用 string.in
+	msgs[0].Enums = msgs[0].Enums[1:]
+	msgs[0].Fields[6].Type = protoreflect.StringKind
+
+	g := &proto.CodeGen{
+		Messages:          msgs,
+		ByName:            "ormat",
+		Version:           "v1.0.0",
+		PackageName:       "api",
+		DisableDocComment: true,
+		EnableValidate:    true,
+	}
+	got := string(g.Gen().Bytes())
+	for _, want := range []string{
+		`import "buf/validate/validate.proto";`,
+		"uint64 id = 1;",
+		"string name = 2 [(buf.validate.field).required = true, (buf.validate.field).string = {max_len: 64}];",
+		"optional string nickname = 3 [(buf.validate.field).string = {max_len: 32}];",
+		"uint32 age = 4 [(buf.validate.field).uint32 = {lte: 255}];",
+		"int32 level = 5 [(buf.validate.field).int32 = {gte: -32768, lte: 32767}];",
+		"bool enabled = 6;",
+		`string status = 7 [(buf.validate.field).string = {in: ["active", "inactive"]}];`,
+		"UserSex sex = 8 [(buf.validate.field).required = true, (buf.validate.field).enum = {defined_only: true}];",
+		"uint32 port = 9;",
+		"int32 score = 10;",
+	} {
+		if !slices.Contains(strings.Split(got, "\n"), want) && !strings.Contains(got, "  "+want+"\n") {
+			t.Errorf("want line %q in\n%s", want, got)
+		}
+	}
+}
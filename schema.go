@@ -14,8 +14,14 @@ type Schema struct {
 
 func (s *Schema) IntoProto() *proto.Schema {
 	entities := make([]*proto.Message, 0, len(s.Entities))
+	enums := make([]*proto.Enum, 0, len(s.Entities))
 	for _, entity := range s.Entities {
-		entities = append(entities, entity.IntoProto())
+		msg := entity.IntoProto()
+		entities = append(entities, msg)
+		enums = append(enums, msg.Enums...)
+	}
+	for _, msg := range entities { // 引用其它表生成的枚举
+		resolveProtoEnums(msg.Fields, enums)
 	}
 	return &proto.Schema{
 		Name:     s.Name,
@@ -0,0 +1,56 @@
+package sqlx
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type MigrationCodeGen struct {
+	buf               bytes.Buffer
+	Statements        []string // required, Migration.Up or Migration.Down
+	ByName            string   // required, 生成名称
+	Version           string   // required, 生成版本
+	DisableDocComment bool     // 禁用doc注释
+}
+
+// Bytes returns the CodeBuf's buffer.
+func (g *MigrationCodeGen) Bytes() []byte {
+	return g.buf.Bytes()
+}
+
+// Write appends the contents of p to the buffer,
+func (g *MigrationCodeGen) Write(b []byte) (n int, err error) {
+	return g.buf.Write(b)
+}
+
+// Print formats using the default formats for its operands and writes to the generated output.
+// Spaces are added between operands when neither is a string.
+// It returns the number of bytes written and any write error encountered.
+func (g *MigrationCodeGen) Print(a ...any) (n int, err error) {
+	return fmt.Fprint(&g.buf, a...)
+}
+
+// Printf formats according to a format specifier for its operands and writes to the generated output.
+// It returns the number of bytes written and any write error encountered.
+func (g *MigrationCodeGen) Printf(format string, a ...any) (n int, err error) {
+	return fmt.Fprintf(&g.buf, format, a...)
+}
+
+// Fprintln formats using the default formats to the generated output.
+// Spaces are always added between operands and a newline is appended.
+// It returns the number of bytes written and any write error encountered.
+func (g *MigrationCodeGen) Println(a ...any) (n int, err error) {
+	return fmt.Fprintln(&g.buf, a...)
+}
+
+func (g *MigrationCodeGen) Gen() *MigrationCodeGen {
+	if !g.DisableDocComment {
+		g.Printf("-- Code generated by %s. DO NOT EDIT.\n", g.ByName) // nolint: errcheck
+		g.Printf("-- version: %s\n", g.Version)                       // nolint: errcheck
+		g.Println()                                                   // nolint: errcheck
+	}
+	for _, stmt := range g.Statements {
+		g.Printf("%s;\n", stmt) // nolint: errcheck
+	}
+	return g
+}
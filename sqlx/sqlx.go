@@ -10,3 +10,9 @@ type Schema struct {
 	Name     string
 	Entities []*Table
 }
+
+type Migration struct {
+	Name string   // 迁移名称
+	Up   []string // 升级sql语句
+	Down []string // 回滚sql语句
+}
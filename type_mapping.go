@@ -0,0 +1,332 @@
+package ens
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/things-go/ens/matcher"
+	"github.com/things-go/ens/utils"
+)
+
+// TypeMapping the rule of mapping the database column type into the go type.
+type TypeMapping struct {
+	Pattern  string  // regexp of the column type, like `^(point)\b`
+	Type     GoType  // go type
+	Nullable *GoType // go type of the nullable column, like sql.NullString, nil means pointer of Type.
+}
+
+// GoType returns the go type of the column, and whether the field need pointer.
+func (m *TypeMapping) GoType(nullable bool) (GoType, bool) {
+	if nullable && m.Nullable != nil {
+		return *m.Nullable, false
+	}
+	return m.Type, nullable
+}
+
+// TypeMapper the ordered type mapping rules, the first matched rule is used.
+type TypeMapper struct {
+	mu    sync.RWMutex
+	rules []*typeMappingRule
+}
+
+type typeMappingRule struct {
+	TypeMapping
+	re *regexp.Regexp
+}
+
+// NewTypeMapper returns a type mapper with the mappings.
+func NewTypeMapper(mappings ...TypeMapping) (*TypeMapper, error) {
+	m := &TypeMapper{}
+	if err := m.Append(mappings...); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MustTypeMapper same as NewTypeMapper, but panic if the pattern is invalid.
+func MustTypeMapper(mappings ...TypeMapping) *TypeMapper {
+	m, err := NewTypeMapper(mappings...)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Append the mappings with the lowest priority.
+func (m *TypeMapper) Append(mappings ...TypeMapping) error {
+	rules, err := compileTypeMappings(mappings)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rules = append(m.rules, rules...)
+	return nil
+}
+
+// Register the mappings with the highest priority, override the existing mappings.
+func (m *TypeMapper) Register(mappings ...TypeMapping) error {
+	rules, err := compileTypeMappings(mappings)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rules = append(rules, m.rules...)
+	return nil
+}
+
+// Match returns the first mapping matched the column type.
+func (m *TypeMapper) Match(columnType string) (*TypeMapping, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, rule := range m.rules {
+		if rule.re.MatchString(columnType) {
+			return &rule.TypeMapping, true
+		}
+	}
+	return nil, false
+}
+
+// Reset remove all the mappings.
+func (m *TypeMapper) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rules = nil
+}
+
+func compileTypeMappings(mappings []TypeMapping) ([]*typeMappingRule, error) {
+	rules := make([]*typeMappingRule, 0, len(mappings))
+	for _, v := range mappings {
+		re, err := regexp.Compile(v.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("type mapping %q: %w", v.Pattern, err)
+		}
+		rules = append(rules, &typeMappingRule{TypeMapping: v, re: re})
+	}
+	return rules, nil
+}
+
+// typeMappings the user type mappings, take precedence over the builtin mappings of the drivers.
+var typeMappings = &TypeMapper{}
+
+// RegisterTypeMapping register the user type mappings, the later registered take precedence.
+// the column type is the raw type of the column, like `varchar(64)`, `int unsigned`, `point`.
+func RegisterTypeMapping(mappings ...TypeMapping) error {
+	return typeMappings.Register(mappings...)
+}
+
+// ResetTypeMapping remove all the user type mappings.
+func ResetTypeMapping() {
+	typeMappings.Reset()
+}
+
+// MatchTypeMapping returns the go type of the column type by the user type mappings, and whether the field need pointer.
+// ok is false if no mapping matched.
+func MatchTypeMapping(columnType string, nullable bool) (t GoType, pointer, ok bool) {
+	m, ok := typeMappings.Match(columnType)
+	if !ok {
+		return GoType{}, false, false
+	}
+	t, pointer = m.GoType(nullable)
+	return t, pointer, true
+}
+
+// NewCustomGoType returns the go type of the identifier, like `orb.Point` in `github.com/paulmach/orb`.
+func NewCustomGoType(t Type, ident, pkgPath string) GoType {
+	return GoType{
+		Type:         t,
+		Ident:        ident,
+		PkgPath:      pkgPath,
+		PkgQualifier: utils.PkgQualifier(ident),
+		NonPointer:   strings.HasPrefix(ident, "[]") || strings.HasPrefix(ident, "*") || strings.HasPrefix(ident, "map["),
+	}
+}
+
+var typeKinds = map[string]Type{
+	"bool":    TypeBool,
+	"int8":    TypeInt8,
+	"int16":   TypeInt16,
+	"int32":   TypeInt32,
+	"int64":   TypeInt64,
+	"int":     TypeInt,
+	"uint8":   TypeUint8,
+	"uint16":  TypeUint16,
+	"uint32":  TypeUint32,
+	"uint64":  TypeUint64,
+	"uint":    TypeUint,
+	"float32": TypeFloat32,
+	"float64": TypeFloat64,
+	"decimal": TypeDecimal,
+	"string":  TypeString,
+	"enum":    TypeEnum,
+	"bytes":   TypeBytes,
+	"time":    TypeTime,
+	"date":    TypeDate,
+	"json":    TypeJSON,
+	"uuid":    TypeUUID,
+	"other":   TypeOther,
+}
+
+// ParseType returns the type of the kind name, like `int64`, `string`, `bytes`, `time`, `json`, `other`.
+// empty name is TypeOther.
+func ParseType(name string) (Type, error) {
+	if name == "" {
+		return TypeOther, nil
+	}
+	t, ok := typeKinds[strings.ToLower(name)]
+	if !ok {
+		return TypeInvalid, fmt.Errorf("unknown type kind %q", name)
+	}
+	return t, nil
+}
+
+// builtinIdentTypes the type of the builtin identifier.
+var builtinIdentTypes = map[string]Type{
+	"bool":      TypeBool,
+	"int8":      TypeInt8,
+	"int16":     TypeInt16,
+	"int32":     TypeInt32,
+	"int64":     TypeInt64,
+	"int":       TypeInt,
+	"uint8":     TypeUint8,
+	"byte":      TypeUint8,
+	"uint16":    TypeUint16,
+	"uint32":    TypeUint32,
+	"uint64":    TypeUint64,
+	"uint":      TypeUint,
+	"float32":   TypeFloat32,
+	"float64":   TypeFloat64,
+	"string":    TypeString,
+	"[]byte":    TypeBytes,
+	"time.Time": TypeTime,
+}
+
+// ParseGoType parse the go type of the full identifier, like `github.com/shopspring/decimal.Decimal`,
+// `*time.Time`, `[]byte`, `int64`, `gopkg.in/yaml.v3.Node`.
+// the Type is the type of the builtin identifier, otherwise t.
+func ParseGoType(s string, t Type) (GoType, error) {
+	s = strings.TrimSpace(s)
+	name := strings.TrimLeft(s, "*")
+	for strings.HasPrefix(name, "[]") {
+		name = strings.TrimLeft(strings.TrimPrefix(name, "[]"), "*")
+	}
+	prefix := s[:len(s)-len(name)]
+	if name == "" {
+		return GoType{}, fmt.Errorf("invalid go type %q", s)
+	}
+	nonPointer := prefix != ""
+	kindPrefix := strings.TrimLeft(prefix, "*") // *int64 同 int64, []byte 为 bytes
+	slash := strings.LastIndexByte(name, '/')
+	dot := strings.LastIndexByte(name, '.')
+	if dot < slash {
+		return GoType{}, fmt.Errorf("invalid go type %q, missing type name", s)
+	}
+	if dot == -1 { // builtin
+		if v, ok := builtinIdentTypes[kindPrefix+name]; ok {
+			t = v
+		}
+		return GoType{
+			Type:         t,
+			Ident:        prefix + name,
+			PkgPath:      "",
+			PkgQualifier: "",
+			NonPointer:   nonPointer,
+		}, nil
+	}
+	pkgPath, typeName := name[:dot], name[dot+1:]
+	if pkgPath == "" || typeName == "" {
+		return GoType{}, fmt.Errorf("invalid go type %q", s)
+	}
+	qualifier := pkgQualifierOf(pkgPath)
+	if v, ok := builtinIdentTypes[kindPrefix+"time."+typeName]; ok && pkgPath == "time" {
+		t = v
+	}
+	return GoType{
+		Type:         t,
+		Ident:        prefix + qualifier + "." + typeName,
+		PkgPath:      pkgPath,
+		PkgQualifier: qualifier,
+		NonPointer:   nonPointer,
+	}, nil
+}
+
+// pkgQualifierOf returns the package qualifier of the import path,
+// like `github.com/go-redis/redis/v8` -> `redis`, `gopkg.in/yaml.v3` -> `yaml`.
+func pkgQualifierOf(pkgPath string) string {
+	base := path.Base(pkgPath)
+	if isMajorVersion(base) && path.Dir(pkgPath) != "." {
+		base = path.Base(path.Dir(pkgPath))
+	}
+	if i := strings.Index(base, ".v"); i > 0 && isMajorVersion(base[i+1:]) {
+		base = base[:i]
+	}
+	base = strings.TrimPrefix(base, "go-")
+	return strings.NewReplacer("-", "_", ".", "_").Replace(base)
+}
+
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// AnnotatedGoType returns the go type of the comment annotation, and whether the field need pointer.
+// ok is false if no annotation or the annotation is invalid.
+//
+//	[@gotype: github.com/shopspring/decimal.Decimal]: used as is, the Type keep t if the identifier is not builtin.
+//	[@jsontype: Settings]: the json column bound to the element type, see JSONGoType.
+func AnnotatedGoType(comment string, t Type, nullable bool) (gt GoType, pointer, ok bool) {
+	if ident := matcher.GoType(comment); ident != "" {
+		gt, err := ParseGoType(ident, t)
+		return gt, false, err == nil
+	}
+	if elem := matcher.JSONType(comment); elem != "" {
+		gt, err := JSONGoType(elem)
+		return gt, nullable && !gt.NonPointer, err == nil
+	}
+	return GoType{}, false, false
+}
+
+// OverrideGoType override the go type of the fields, TableName -> ColumnName -> the full identifier,
+// the type is used as is, like `*int64` for pointer.
+func (s *Schema) OverrideGoType(idents map[string]map[string]string) error {
+	var errs []error
+	for _, et := range s.Entities {
+		columns := idents[et.Name]
+		if len(columns) == 0 {
+			continue
+		}
+		for _, field := range et.Fields {
+			ident := columns[field.ColumnName]
+			if ident == "" {
+				continue
+			}
+			if err := field.overrideGoType(ident); err != nil {
+				errs = append(errs, fmt.Errorf("%s.%s: %w", et.Name, field.ColumnName, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (field *FieldDescriptor) overrideGoType(ident string) error {
+	t, err := ParseGoType(ident, field.Type.Type)
+	if err != nil {
+		return err
+	}
+	field.Type = t
+	field.GoPointer = false
+	field.CustomType = true
+	return nil
+}
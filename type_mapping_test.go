@@ -0,0 +1,100 @@
+package ens_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/rapier"
+)
+
+func Test_ParseGoType(t *testing.T) {
+	tests := []struct {
+		ident   string
+		want    ens.GoType
+		wantErr bool
+	}{
+		{"github.com/shopspring/decimal.Decimal", ens.GoType{Type: ens.TypeDecimal, Ident: "decimal.Decimal", PkgPath: "github.com/shopspring/decimal", PkgQualifier: "decimal"}, false},
+		{"*github.com/go-redis/redis/v8.Client", ens.GoType{Type: ens.TypeDecimal, Ident: "*redis.Client", PkgPath: "github.com/go-redis/redis/v8", PkgQualifier: "redis", NonPointer: true}, false},
+		{"gopkg.in/yaml.v3.Node", ens.GoType{Type: ens.TypeDecimal, Ident: "yaml.Node", PkgPath: "gopkg.in/yaml.v3", PkgQualifier: "yaml"}, false},
+		{"time.Time", ens.GoType{Type: ens.TypeTime, Ident: "time.Time", PkgPath: "time", PkgQualifier: "time"}, false},
+		{"int64", ens.GoType{Type: ens.TypeInt64, Ident: "int64"}, false},
+		{"*int64", ens.GoType{Type: ens.TypeInt64, Ident: "*int64", NonPointer: true}, false},
+		{"[]byte", ens.GoType{Type: ens.TypeBytes, Ident: "[]byte", NonPointer: true}, false},
+		{"github.com/shopspring/decimal", ens.GoType{}, true},
+		{"", ens.GoType{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ident, func(t *testing.T) {
+			got, err := ens.ParseGoType(tt.ident, ens.TypeDecimal)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGoType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseGoType() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GoTypeOverride(t *testing.T) {
+	sc := parseSQL(t, "CREATE TABLE `order` (\n"+
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n"+
+		"  `price` decimal(10,2) DEFAULT NULL COMMENT '价格 [@gotype: *github.com/shopspring/decimal.Decimal]',\n"+
+		"  `amount` decimal(10,2) NOT NULL COMMENT '金额',\n"+
+		"  `status` tinyint NOT NULL COMMENT '状态 [@enum: 1:Paid,2:Closed] [@gotype: int32]',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+	err := sc.OverrideGoType(map[string]map[string]string{
+		"order": {"amount": "github.com/shopspring/decimal.Decimal"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sc.OverrideGoType(map[string]map[string]string{"order": {"amount": "github.com/shopspring/decimal"}}); err == nil {
+		t.Error("want error of the invalid ident")
+	}
+
+	g := &ens.CodeGen{
+		Entities:          sc.Entities,
+		ByName:            "ormat",
+		Version:           "v1.0.0",
+		PackageName:       "model",
+		DisableDocComment: true,
+		Option: ens.Option{
+			EnableInt: true,
+			Tags:      map[string]string{"json": "snakeCase"},
+		},
+	}
+	data := string(g.Gen().Bytes())
+	got := strings.Join(strings.Fields(data), " ")
+	for _, want := range []string{
+		`"github.com/shopspring/decimal"`,
+		"Price *decimal.Decimal `gorm:",
+		"Amount decimal.Decimal `gorm:",
+		"Status int32 `gorm:", // 自定义类型原样使用, 不转换为 int, 不生成枚举
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, data)
+		}
+	}
+	if strings.Contains(got, "type OrderStatus") {
+		t.Errorf("unexpected enum OrderStatus in\n%s", data)
+	}
+
+	fields := map[string]*ens.FieldDescriptor{}
+	for _, field := range sc.Entities[0].Fields {
+		fields[field.ColumnName] = field
+	}
+	if got := fields["price"].IntoProto(); got.Type != protoreflect.StringKind {
+		t.Errorf("price proto kind = %v, want string", got.Type)
+	}
+	if got := fields["amount"].IntoRapier(); got.Type != rapier.Decimal {
+		t.Errorf("amount rapier type = %v, want decimal", got.Type)
+	}
+	if got := fields["status"].IntoRapier(); got.Type != rapier.Int32 {
+		t.Errorf("status rapier type = %v, want int32", got.Type)
+	}
+}
@@ -0,0 +1,66 @@
+package ens
+
+import (
+	"regexp"
+)
+
+// TypeOption the switchable mappings of the column types, the builtin mappings are used if disabled.
+type TypeOption struct {
+	EnableDatatypesDate bool `yaml:"enableDatatypesDate" json:"enableDatatypesDate"` // 使能date输出datatypes.Date
+	EnableDatatypesTime bool `yaml:"enableDatatypesTime" json:"enableDatatypesTime"` // 使能time输出datatypes.Time
+	EnableYearInt       bool `yaml:"enableYearInt" json:"enableYearInt"`             // 使能year输出int16
+	EnableDecimal       bool `yaml:"enableDecimal" json:"enableDecimal"`             // 使能decimal输出decimal.Decimal(github.com/shopspring/decimal)
+	EnableUUID          bool `yaml:"enableUUID" json:"enableUUID"`                   // 使能binary(16)输出datatypes.BinUUID, char(36),uuid输出datatypes.UUID
+	EnableBitBool       bool `yaml:"enableBitBool" json:"enableBitBool"`             // 使能bit(1)输出bool
+}
+
+// typeOptionRules the switchable mappings, match the raw column type.
+var typeOptionRules = []struct {
+	Enabled func(o *TypeOption) bool
+	Re      *regexp.Regexp
+	NewType func() GoType
+}{
+	{func(o *TypeOption) bool { return o.EnableDatatypesDate }, regexp.MustCompile(`(?i)^date$`), DatatypesDateType},
+	{func(o *TypeOption) bool { return o.EnableDatatypesTime }, regexp.MustCompile(`(?i)^time\b`), DatatypesTimeType},
+	{func(o *TypeOption) bool { return o.EnableYearInt }, regexp.MustCompile(`(?i)^year\b`), Int16Type},
+	{func(o *TypeOption) bool { return o.EnableDecimal }, regexp.MustCompile(`(?i)^(decimal|numeric)\b`), ShopspringDecimalType},
+	{func(o *TypeOption) bool { return o.EnableUUID }, regexp.MustCompile(`(?i)^binary[(]16[)]$`), DatatypesBinUUIDType},
+	{func(o *TypeOption) bool { return o.EnableUUID }, regexp.MustCompile(`(?i)^(char[(]36[)]|uuid)$`), DatatypesUUIDType},
+	{func(o *TypeOption) bool { return o.EnableBitBool }, regexp.MustCompile(`(?i)^bit[(]1[)]$`), BoolType},
+}
+
+// IsZero reports whether all the mappings are disabled.
+func (o *TypeOption) IsZero() bool {
+	return o == nil || *o == TypeOption{}
+}
+
+// ApplyTypeOption apply the switchable mappings to the fields,
+// the field customized by `[@gotype: xxx]`, `[@jsontype: xxx]` or the user type mappings is kept as is.
+func (s *Schema) ApplyTypeOption(o *TypeOption) {
+	if o.IsZero() {
+		return
+	}
+	for _, et := range s.Entities {
+		for _, field := range et.Fields {
+			field.applyTypeOption(o)
+		}
+	}
+}
+
+func (field *FieldDescriptor) applyTypeOption(o *TypeOption) {
+	if o.IsZero() || field.CustomType ||
+		field.Column == nil || field.Column.Column() == nil || field.Column.Column().Type == nil {
+		return
+	}
+	columnType := field.Column.Column().Type.Raw
+	if _, ok := typeMappings.Match(columnType); ok { // 用户类型映射优先
+		return
+	}
+	for _, rule := range typeOptionRules {
+		if rule.Enabled(o) && rule.Re.MatchString(columnType) {
+			field.Type = rule.NewType()
+			field.GoPointer = field.Nullable && !field.Type.NonPointer
+			return
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package ens_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/proto"
+	"github.com/things-go/ens/rapier"
+)
+
+const typeOptionSQL = "CREATE TABLE `event` (\n" +
+	"  `id` bigint NOT NULL AUTO_INCREMENT,\n" +
+	"  `day` date NOT NULL COMMENT '日期',\n" +
+	"  `at` time DEFAULT NULL COMMENT '时间',\n" +
+	"  `year` year NOT NULL COMMENT '年份',\n" +
+	"  `price` decimal(10,2) DEFAULT NULL COMMENT '价格',\n" +
+	"  `bin_id` binary(16) NOT NULL COMMENT '二进制uuid',\n" +
+	"  `str_id` char(36) NOT NULL COMMENT '字符串uuid',\n" +
+	"  `flag` bit(1) NOT NULL COMMENT '标识',\n" +
+	"  `amount` decimal(10,2) NOT NULL COMMENT '金额 [@gotype: float64]',\n" +
+	"  PRIMARY KEY (`id`)\n" +
+	") ENGINE=InnoDB;"
+
+func Test_TypeOption(t *testing.T) {
+	g := &ens.CodeGen{
+		Entities:          parseSQL(t, typeOptionSQL).Entities,
+		ByName:            "ormat",
+		Version:           "v1.0.0",
+		PackageName:       "model",
+		DisableDocComment: true,
+		Option: ens.Option{
+			DisableNullToPoint: true,
+			EnableInt:          true,
+			Tags:               map[string]string{"json": "snakeCase"},
+			TypeOption: ens.TypeOption{
+				EnableDatatypesDate: true,
+				EnableDatatypesTime: true,
+				EnableYearInt:       true,
+				EnableDecimal:       true,
+				EnableUUID:          true,
+				EnableBitBool:       true,
+			},
+		},
+	}
+	data := string(g.Gen().Bytes())
+	got := strings.Join(strings.Fields(data), " ")
+	for _, want := range []string{
+		`"github.com/shopspring/decimal"`,
+		"Day datatypes.Date `gorm:",
+		"At sql.Null[datatypes.Time] `gorm:", // 无对应的 sql.Nullxx 时使用 sql.Null[T]
+		"Year int `gorm:",
+		"Price sql.Null[decimal.Decimal] `gorm:",
+		"BinId datatypes.BinUUID `gorm:",
+		"StrId datatypes.UUID `gorm:",
+		"Flag bool `gorm:",
+		"Amount float64 `gorm:", // 自定义类型优先
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, data)
+		}
+	}
+
+	// 默认保持内置映射
+	g = &ens.CodeGen{
+		Entities:          parseSQL(t, typeOptionSQL).Entities,
+		ByName:            "ormat",
+		Version:           "v1.0.0",
+		PackageName:       "model",
+		DisableDocComment: true,
+		Option:            ens.Option{Tags: map[string]string{"json": "snakeCase"}},
+	}
+	data = string(g.Gen().Bytes())
+	got = strings.Join(strings.Fields(data), " ")
+	for _, want := range []string{
+		"Day time.Time `gorm:",
+		"Price *string `gorm:",
+		"BinId []uint8 `gorm:",
+		"StrId string `gorm:",
+		"Flag []uint8 `gorm:",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, data)
+		}
+	}
+}
+
+func Test_TypeOption_ProtoRapier(t *testing.T) {
+	sc := parseSQL(t, typeOptionSQL)
+	sc.ApplyTypeOption(&ens.TypeOption{
+		EnableDatatypesDate: true,
+		EnableYearInt:       true,
+		EnableUUID:          true,
+		EnableBitBool:       true,
+	})
+	fields := map[string]*ens.FieldDescriptor{}
+	for _, field := range sc.Entities[0].Fields {
+		fields[field.ColumnName] = field
+	}
+	tests := []struct {
+		column   string
+		kind     protoreflect.Kind
+		typeName string
+		rapier   rapier.Type
+	}{
+		{"day", protoreflect.MessageKind, "google.type.Date", rapier.Time},
+		{"year", protoreflect.Int32Kind, "int32", rapier.Int16},
+		{"bin_id", protoreflect.StringKind, "string", rapier.UUID},
+		{"str_id", protoreflect.StringKind, "string", rapier.UUID},
+		{"flag", protoreflect.BoolKind, "bool", rapier.Bool},
+		{"price", protoreflect.StringKind, "string", rapier.Decimal},
+	}
+	for _, tt := range tests {
+		field := fields[tt.column]
+		if got := field.IntoProto(); got.Type != tt.kind || got.TypeName != tt.typeName {
+			t.Errorf("%s proto = %v %s, want %v %s", tt.column, got.Type, got.TypeName, tt.kind, tt.typeName)
+		}
+		if got := field.IntoRapier(); got.Type != tt.rapier {
+			t.Errorf("%s rapier = %v, want %v", tt.column, got.Type, tt.rapier)
+		}
+	}
+
+	g := &proto.CodeGen{
+		Messages:          sc.IntoProto().Entities,
+		ByName:            "ormat",
+		Version:           "v1.0.0",
+		PackageName:       "api",
+		DisableDocComment: true,
+	}
+	if data := string(g.Gen().Bytes()); !strings.Contains(data, `import "google/type/date.proto";`) ||
+		!strings.Contains(data, "google.type.Date day = ") {
+		t.Errorf("want google.type.Date in\n%s", data)
+	}
+}
+
+func Test_TypeDate_Value(t *testing.T) {
+	// the new type is appended, the exported values must be kept.
+	for _, tt := range []struct {
+		t    ens.Type
+		want int
+	}{
+		{ens.TypeTime, 18},
+		{ens.TypeJSON, 19},
+		{ens.TypeUUID, 20},
+		{ens.TypeOther, 21},
+		{ens.TypeDate, 22},
+	} {
+		if int(tt.t) != tt.want {
+			t.Errorf("%s = %d, want %d", tt.t, tt.t, tt.want)
+		}
+	}
+	if got := ens.TypeDate.String(); got != "datatypes.Date" {
+		t.Errorf("TypeDate.String() = %s, want datatypes.Date", got)
+	}
+}
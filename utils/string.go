@@ -129,6 +129,36 @@ func PascalCase(s string) string {
 	return JoinCase(s, '_')
 }
 
+// GoCamelCase 转换为 protoc-gen-go 生成的字段名, 同 google.golang.org/protobuf/internal/strs.GoCamelCase
+// user_name -> UserName
+// userName -> UserName
+// _id -> XId
+func GoCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isASCIILower(s[i+1]):
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
 // LowTitle 首字母小写
 // see strings.Title
 func LowTitle(s string) string {
@@ -5,6 +5,8 @@ const (
 	StylePascalCase     = "pascalCase"
 	StyleSnakeCase      = "snakeCase"
 	StyleKebab          = "kebab"
+	// StyleValidator not a name style, the tag value is the validator rules derived from the column, like `binding:"required,max=64"`
+	StyleValidator = "validator"
 )
 
 func StyleName(kind, name string) string {
@@ -0,0 +1,68 @@
+package ens
+
+import (
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/things-go/ens/matcher"
+)
+
+// validatorRules returns the go-playground/validator rules of the field, like `required,max=64`.
+// derived from the column metadata and the comment annotation `[@validate: email]`.
+func (field *FieldDescriptor) validatorRules() string {
+	rules := make([]string, 0, 4)
+	required := false
+	if c := field.intoConstraint(); c != nil {
+		// bool 的零值 false 是合法值
+		required = c.Required && !field.Type.IsBool()
+		if required {
+			rules = append(rules, "required")
+		}
+		// sql.NullXXX, time.Time, datatypes.JSON ... validator 不支持 max, oneof 等规则
+		if field.Type.PkgPath == "" {
+			if c.MaxLen > 0 && !field.Type.IsNumeric() {
+				rules = append(rules, "max="+strconv.FormatInt(c.MaxLen, 10))
+			}
+			if v := oneofRule(field.enumValues()); v != "" {
+				rules = append(rules, v)
+			}
+			if field.Column.Column().Type != nil {
+				if t, ok := field.Column.Column().Type.Type.(*schema.IntegerType); ok && t.Unsigned {
+					rules = append(rules, "gte=0")
+				}
+			}
+		}
+	}
+	if v := matcher.Validate(field.Comment); v != "" {
+		rules = append(rules, v)
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+	if !required { // 非必填时, 零值不校验
+		rules = append([]string{"omitempty"}, rules...)
+	}
+	return strings.Join(rules, ",")
+}
+
+// oneofRule returns the oneof rule of the enum values, like `oneof=male female`,
+// the value contain space is quoted by `'`, empty if the value can not be represented.
+func oneofRule(values []matcher.EnumValue) string {
+	if len(values) == 0 {
+		return ""
+	}
+	vs := make([]string, 0, len(values))
+	for _, v := range values {
+		if v.Value == "" || strings.ContainsAny(v.Value, ",|'\"`") {
+			return ""
+		}
+		if strings.ContainsAny(v.Value, " \t") {
+			vs = append(vs, "'"+v.Value+"'")
+		} else {
+			vs = append(vs, v.Value)
+		}
+	}
+	return "oneof=" + strings.Join(vs, " ")
+}
@@ -0,0 +1,56 @@
+package ens_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/things-go/ens"
+	"github.com/things-go/ens/utils"
+)
+
+func Test_ValidatorTag(t *testing.T) {
+	sc := parseSQL(t, "CREATE TABLE `user` (\n"+
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `name` varchar(64) NOT NULL COMMENT '名称',\n"+
+		"  `email` varchar(128) NOT NULL DEFAULT '' COMMENT '邮箱 [@validate: email]',\n"+
+		"  `homepage` varchar(255) DEFAULT NULL COMMENT '主页 [@validate: url]',\n"+
+		"  `status` enum('active','in_progress') NOT NULL COMMENT '状态',\n"+
+		"  `age` int unsigned NOT NULL DEFAULT '0' COMMENT '年龄',\n"+
+		"  `enabled` tinyint(1) NOT NULL COMMENT '启用',\n"+
+		"  `remark` text COMMENT '备注',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;")
+
+	g := &ens.CodeGen{
+		Entities:          sc.Entities,
+		ByName:            "ormat",
+		Version:           "v1.0.0",
+		PackageName:       "model",
+		DisableDocComment: true,
+		Option: ens.Option{
+			Tags: map[string]string{"binding": utils.StyleValidator},
+		},
+	}
+	data, err := g.Gen().FormatSource()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		`binding:"omitempty,gte=0"`,                   // id
+		`binding:"required,max=64"`,                   // name
+		`binding:"omitempty,max=128,email"`,           // email
+		`binding:"omitempty,max=255,url"`,             // homepage
+		`binding:"required,oneof=active in_progress"`, // status
+		`binding:"omitempty,gte=0"`,                   // age
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, data)
+		}
+	}
+	for _, line := range strings.Split(got, "\n") {
+		if (strings.Contains(line, "Enabled") || strings.Contains(line, "Remark")) && strings.Contains(line, "binding:") {
+			t.Errorf("unexpected binding tag: %s", line)
+		}
+	}
+}